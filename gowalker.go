@@ -16,21 +16,12 @@
 package main
 
 import (
-	"fmt"
-	"net/http"
-	"strings"
+	"os"
 
-	"github.com/go-macaron/i18n"
-	"github.com/go-macaron/pongo2"
-	"github.com/go-macaron/session"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/urfave/cli"
 	log "gopkg.in/clog.v1"
-	"gopkg.in/macaron.v1"
 
-	"github.com/unknwon/gowalker/internal/context"
-	_ "github.com/unknwon/gowalker/internal/prometheus"
-	"github.com/unknwon/gowalker/internal/route"
-	"github.com/unknwon/gowalker/internal/route/apiv1"
+	"github.com/unknwon/gowalker/internal/cmd"
 	"github.com/unknwon/gowalker/internal/setting"
 )
 
@@ -40,58 +31,28 @@ func init() {
 	setting.AppVer = Version
 }
 
-// newMacaron initializes Macaron instance.
-func newMacaron() *macaron.Macaron {
-	m := macaron.New()
-	if !setting.DisableRouterLog {
-		m.Use(macaron.Logger())
-	}
-	m.Use(macaron.Recovery())
-	m.Use(macaron.Static("public",
-		macaron.StaticOptions{
-			SkipLogging: setting.ProdMode,
-		},
-	))
-	m.Use(macaron.Static("raw",
-		macaron.StaticOptions{
-			Prefix:      "raw",
-			SkipLogging: setting.ProdMode,
-		}))
-	m.Use(pongo2.Pongoer(pongo2.Options{
-		IndentJSON: !setting.ProdMode,
-	}))
-	m.Use(i18n.I18n())
-	m.Use(session.Sessioner())
-	m.Use(context.Contexter())
-	return m
-}
-
 func main() {
-	log.Info("Go Walker %s", Version)
-	log.Info("Run Mode: %s", strings.Title(macaron.Env))
-
-	m := newMacaron()
-	m.Get("/", route.Home)
-	m.Get("/search", route.Search)
-	m.Get("/search/json", route.SearchJSON)
-
-	m.Group("/api", func() {
-		m.Group("/v1", func() {
-			m.Get("/badge", apiv1.Badge)
-		})
-	})
-
-	m.Get("/-/metrics", promhttp.Handler())
-
-	m.Get("/robots.txt", func() string {
-		return `User-agent: *
-Disallow: /search`
-	})
-	m.Get("/*", route.Docs)
-
-	listenAddr := fmt.Sprintf("0.0.0.0:%d", setting.HTTPPort)
-	log.Info("Listen: http://%s", listenAddr)
-	if err := http.ListenAndServe(listenAddr, m); err != nil {
-		log.Fatal(2, "Failed to start server: %v", err)
+	app := cli.NewApp()
+	app.Name = "Go Walker"
+	app.Usage = "Go online API documentation with source code analysis"
+	app.Version = Version
+	app.Commands = []cli.Command{
+		cmd.Serve,
+		cmd.Crawl,
+		cmd.Prewarm,
+		cmd.Render,
+		cmd.Export,
+		cmd.ExportSite,
+		cmd.Docset,
+		cmd.Markdown,
+		cmd.GC,
+		cmd.Runner,
+		cmd.Sandbox,
+	}
+	// Start web server when no command is given to keep old behavior.
+	app.Flags = cmd.ServeFlags
+	app.Action = cmd.Serve.Action
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(2, "Failed to start application: %v", err)
 	}
 }
@@ -71,6 +71,24 @@ func IsDocFile(n string) bool {
 	return strings.HasPrefix(strings.ToLower(n), "readme")
 }
 
+var licenseFileNames = []string{"license", "licence", "copying", "unlicense"}
+
+// IsLicenseFile returns true if the name is a conventional name of license
+// file, e.g. "LICENSE", "COPYING.txt" and "LICENSE-MIT".
+func IsLicenseFile(n string) bool {
+	n = strings.ToLower(n)
+	for _, ext := range []string{".md", ".txt", ".rst"} {
+		n = strings.TrimSuffix(n, ext)
+	}
+	for _, name := range licenseFileNames {
+		if n == name || strings.HasPrefix(n, name+"-") || strings.HasPrefix(n, name+".") ||
+			strings.HasPrefix(n, name+"_") {
+			return true
+		}
+	}
+	return false
+}
+
 var filterDirNames = []string{
 	"static", "docs", "views", "js", "assets", "public", "img", "css"}
 
@@ -21,11 +21,10 @@ import (
 	"github.com/unknwon/i18n"
 	log "gopkg.in/clog.v1"
 	"gopkg.in/fsnotify.v1"
-
-	"github.com/unknwon/gowalker/internal/setting"
 )
 
-func monitorI18nLocale() {
+// MonitorI18nLocale reloads locale files whenever they are changed on disk.
+func MonitorI18nLocale() {
 	log.Info("Monitor i18n locale files enabled")
 
 	watcher, err := fsnotify.NewWatcher()
@@ -53,12 +52,6 @@ func monitorI18nLocale() {
 	}
 }
 
-func init() {
-	if !setting.ProdMode {
-		monitorI18nLocale()
-	}
-}
-
 func SubStr(str string, start, length int) string {
 	if len(str) == 0 {
 		return ""
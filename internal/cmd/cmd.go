@@ -0,0 +1,79 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/go-macaron/pongo2"
+	"github.com/unknwon/com"
+	"github.com/urfave/cli"
+	"gopkg.in/macaron.v1"
+
+	"github.com/unknwon/gowalker/internal/db"
+	"github.com/unknwon/gowalker/internal/setting"
+)
+
+func stringFlag(name, value, usage string) cli.StringFlag {
+	return cli.StringFlag{
+		Name:  name,
+		Value: value,
+		Usage: usage,
+	}
+}
+
+func boolFlag(name, usage string) cli.BoolFlag {
+	return cli.BoolFlag{
+		Name:  name,
+		Usage: usage,
+	}
+}
+
+var configFlag = stringFlag("config, c", "custom/app.ini", "Custom configuration file path")
+
+// initSetting loads configuration, the file must exist when it is given explicitly.
+func initSetting(c *cli.Context) error {
+	customConf := c.String("config")
+	if c.IsSet("config") && !com.IsFile(customConf) {
+		return fmt.Errorf("configuration file '%s' does not exist", customConf)
+	}
+	setting.Init(customConf)
+	return nil
+}
+
+// globalInit loads configuration and connects to the database.
+func globalInit(c *cli.Context) error {
+	if err := initSetting(c); err != nil {
+		return err
+	}
+	db.Init()
+	return nil
+}
+
+// newRender returns a template renderer that works outside of HTTP requests.
+func newRender() macaron.Render {
+	var render macaron.Render
+	m := macaron.New()
+	m.Use(pongo2.Pongoer(pongo2.Options{
+		IndentJSON: !setting.ProdMode,
+	}))
+	m.Get("/", func(r macaron.Render) {
+		render = r
+	})
+	m.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	return render
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli"
+	log "gopkg.in/clog.v1"
+
+	"github.com/unknwon/gowalker/internal/doc"
+)
+
+var Crawl = cli.Command{
+	Name:      "crawl",
+	Usage:     "Fetch and render documentation of packages",
+	ArgsUsage: "<import path>...",
+	Description: `Crawl forces refresh of given packages from their remote
+repositories, regardless of the refresh interval`,
+	Action: runCrawl,
+	Flags: []cli.Flag{
+		configFlag,
+	},
+}
+
+func runCrawl(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return errors.New("at least one import path is required")
+	}
+	if err := globalInit(c); err != nil {
+		return err
+	}
+
+	render := newRender()
+	failed := 0
+	for _, importPath := range c.Args() {
+		pinfo, err := doc.CheckPackage(importPath, render, doc.RequestTypeRefresh)
+		if err != nil {
+			failed++
+			log.Error(0, "Failed to crawl '%s': %v", importPath, err)
+			continue
+		}
+		log.Info("Crawled '%s': %s", importPath, pinfo.Etag)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d packages failed", failed, c.NArg())
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/urfave/cli"
+	log "gopkg.in/clog.v1"
+
+	"github.com/unknwon/gowalker/internal/doc"
+)
+
+var Docset = cli.Command{
+	Name:      "docset",
+	Usage:     "Generate Dash/Zeal docset of packages",
+	ArgsUsage: "<import path>...",
+	Description: `Docset writes documentation of given packages as a docset bundle
+for Dash and Zeal. Packages must have been crawled with gob files saved`,
+	Action: runDocset,
+	Flags: []cli.Flag{
+		configFlag,
+		boolFlag("tree", "Include all indexed subpackages of given packages"),
+		stringFlag("name", "", "Name of the docset, default is base name of the first import path"),
+		stringFlag("output, o", ".", "Directory to write the docset bundle to"),
+	},
+}
+
+func runDocset(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return errors.New("at least one import path is required")
+	}
+	if err := globalInit(c); err != nil {
+		return err
+	}
+
+	importPaths := []string(c.Args())
+	if c.Bool("tree") {
+		importPaths = nil
+		for _, importPath := range c.Args() {
+			tree, err := doc.PackageTree(importPath)
+			if err != nil {
+				return fmt.Errorf("list subpackages of '%s': %v", importPath, err)
+			}
+			importPaths = append(importPaths, tree...)
+		}
+	}
+
+	name := c.String("name")
+	if len(name) == 0 {
+		name = path.Base(c.Args().First())
+	}
+	docsetPath := filepath.Join(c.String("output"), name+".docset")
+	if err := doc.ExportDocset(newRender(), name, importPaths, "public", docsetPath); err != nil {
+		return err
+	}
+	log.Info("Generated docset of %d packages to '%s'", len(importPaths), docsetPath)
+	return nil
+}
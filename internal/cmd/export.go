@@ -0,0 +1,74 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/unknwon/gowalker/internal/db"
+)
+
+var Export = cli.Command{
+	Name:      "export",
+	Usage:     "Export package information as JSON lines",
+	ArgsUsage: "[<import path>...]",
+	Description: `Export writes information of given packages, or all packages
+matching the prefix, one JSON object per line`,
+	Action: runExport,
+	Flags: []cli.Flag{
+		configFlag,
+		stringFlag("prefix", "", "Export all packages whose import paths start with the prefix"),
+		stringFlag("output, o", "", "Output file path, default is standard output"),
+	},
+}
+
+func runExport(c *cli.Context) error {
+	if err := globalInit(c); err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if c.IsSet("output") {
+		f, err := os.Create(c.String("output"))
+		if err != nil {
+			return fmt.Errorf("create output: %v", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+
+	if c.NArg() == 0 {
+		return db.IteratePkgInfos(c.String("prefix"), func(pinfo *db.PkgInfo) error {
+			return enc.Encode(pinfo)
+		})
+	}
+
+	for _, importPath := range c.Args() {
+		pinfo, err := db.GetPkgInfo(importPath)
+		if err != nil && (err != db.ErrPackageVersionTooOld || pinfo == nil) {
+			return fmt.Errorf("get package info '%s': %v", importPath, err)
+		}
+		if err = enc.Encode(pinfo); err != nil {
+			return fmt.Errorf("encode '%s': %v", importPath, err)
+		}
+	}
+	return nil
+}
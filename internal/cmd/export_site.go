@@ -0,0 +1,66 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli"
+	log "gopkg.in/clog.v1"
+
+	"github.com/unknwon/gowalker/internal/db"
+	"github.com/unknwon/gowalker/internal/doc"
+)
+
+var ExportSite = cli.Command{
+	Name:      "export-site",
+	Usage:     "Export documentation as a static site",
+	ArgsUsage: "[<import path>...]",
+	Description: `Export-site writes documentation of given packages, or all packages
+matching the prefix, as a self-contained static site for offline use.
+Packages must have been crawled with gob files saved`,
+	Action: runExportSite,
+	Flags: []cli.Flag{
+		configFlag,
+		stringFlag("prefix", "", "Export all packages whose import paths start with the prefix"),
+		stringFlag("output, o", "site", "Output directory, it must not exist"),
+	},
+}
+
+func runExportSite(c *cli.Context) error {
+	if c.NArg() == 0 && !c.IsSet("prefix") {
+		return errors.New("import paths or --prefix is required")
+	}
+	if err := globalInit(c); err != nil {
+		return err
+	}
+
+	importPaths := []string(c.Args())
+	if c.NArg() == 0 {
+		if err := db.IteratePkgInfos(c.String("prefix"), func(pinfo *db.PkgInfo) error {
+			importPaths = append(importPaths, pinfo.ImportPath)
+			return nil
+		}); err != nil {
+			return fmt.Errorf("list packages: %v", err)
+		}
+	}
+
+	if err := doc.ExportSite(newRender(), importPaths, "public", c.String("output")); err != nil {
+		return err
+	}
+	log.Info("Exported %d packages to '%s'", len(importPaths), c.String("output"))
+	return nil
+}
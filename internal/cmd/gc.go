@@ -0,0 +1,45 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"github.com/urfave/cli"
+	log "gopkg.in/clog.v1"
+
+	"github.com/unknwon/gowalker/internal/db"
+)
+
+var GC = cli.Command{
+	Name:  "gc",
+	Usage: "Recycle and reconcile generated documentation files",
+	Description: `GC recycles JS files of packages that have not been viewed for a while,
+then removes records of deleted packages and of files missing on disk`,
+	Action: runGC,
+	Flags: []cli.Flag{
+		configFlag,
+	},
+}
+
+func runGC(c *cli.Context) error {
+	if err := globalInit(c); err != nil {
+		return err
+	}
+
+	log.Info("Recycling JS files...")
+	db.RecycleJSFiles()
+	log.Info("Reconciling JS files...")
+	db.ReconcileJSFiles()
+	return nil
+}
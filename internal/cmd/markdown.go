@@ -0,0 +1,59 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/unknwon/gowalker/internal/doc"
+)
+
+var Markdown = cli.Command{
+	Name:      "markdown",
+	Usage:     "Print documentation of a package in Markdown",
+	ArgsUsage: "<import path>",
+	Description: `Markdown renders documentation of the package in GitHub-flavoured Markdown.
+The package must have been crawled with gob files saved`,
+	Action: runMarkdown,
+	Flags: []cli.Flag{
+		configFlag,
+		stringFlag("output, o", "", "Output file path, default is standard output"),
+	},
+}
+
+func runMarkdown(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return errors.New("exactly one import path is required")
+	}
+	if err := globalInit(c); err != nil {
+		return err
+	}
+
+	data, err := doc.RenderMarkdown(c.Args().First())
+	if err != nil {
+		return fmt.Errorf("render '%s': %v", c.Args().First(), err)
+	}
+
+	if c.IsSet("output") {
+		return ioutil.WriteFile(c.String("output"), data, 0644)
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
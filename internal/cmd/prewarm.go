@@ -0,0 +1,80 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/urfave/cli"
+	log "gopkg.in/clog.v1"
+
+	"github.com/unknwon/gowalker/internal/doc"
+)
+
+var Prewarm = cli.Command{
+	Name:      "prewarm",
+	Usage:     "Crawl all packages of modules listed in go.mod or go.sum",
+	ArgsUsage: "<go.mod or go.sum>",
+	Description: `Prewarm crawls packages of every module required by go.mod or listed
+in go.sum, so their documentation is ready before anyone visits. Packages that
+are already documented at the listed versions or were crawled recently are skipped`,
+	Action: runPrewarm,
+	Flags: []cli.Flag{
+		configFlag,
+	},
+}
+
+func runPrewarm(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return errors.New("exactly one go.mod or go.sum is required")
+	}
+	data, err := ioutil.ReadFile(c.Args().First())
+	if err != nil {
+		return fmt.Errorf("read file: %v", err)
+	}
+	if err := globalInit(c); err != nil {
+		return err
+	}
+
+	doc.StartPrewarm(newRender())
+	job, err := doc.Prewarm(data, doc.PrewarmOptions{})
+	if err != nil {
+		return fmt.Errorf("prewarm: %v", err)
+	}
+	log.Info("Prewarm job %s: %d modules", job.ID, job.NumModules)
+
+	numDone := 0
+	for job.Status != doc.PrewarmDone {
+		time.Sleep(time.Second)
+		if job, err = doc.GetPrewarmJob(job.ID); err != nil {
+			return fmt.Errorf("get job: %v", err)
+		}
+		for ; numDone < job.NumDoneModules; numDone++ {
+			m := job.Modules[numDone]
+			if m.Failed > 0 {
+				log.Error(0, "Prewarmed '%s@%s': %d packages, %d failed: %s", m.Path, m.Version, m.Packages, m.Failed, m.Error)
+			} else {
+				log.Info("Prewarmed '%s@%s': %d packages, %d already documented", m.Path, m.Version, m.Packages, m.Cached)
+			}
+		}
+	}
+	if job.NumFailed > 0 {
+		return fmt.Errorf("%d packages failed", job.NumFailed)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli"
+	log "gopkg.in/clog.v1"
+
+	"github.com/unknwon/gowalker/internal/doc"
+	"github.com/unknwon/gowalker/internal/setting"
+)
+
+var Render = cli.Command{
+	Name:      "render",
+	Usage:     "Re-render documentation from stored data",
+	ArgsUsage: "<import path>...",
+	Description: `Render regenerates documentation pages from stored gob files
+without fetching from remote repositories, which is useful after templates change`,
+	Action: runRender,
+	Flags: []cli.Flag{
+		configFlag,
+		boolFlag("all", "Render all packages that have stored gob files"),
+	},
+}
+
+// storedImportPaths returns import paths of all packages that have gob files.
+func storedImportPaths() ([]string, error) {
+	root := filepath.Clean(setting.DocsGobPath)
+	var importPaths []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		} else if info.IsDir() || !strings.HasSuffix(path, ".gob") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, strings.TrimSuffix(path, ".gob"))
+		if err != nil {
+			return err
+		}
+		importPaths = append(importPaths, filepath.ToSlash(rel))
+		return nil
+	})
+	return importPaths, err
+}
+
+func runRender(c *cli.Context) error {
+	if c.NArg() == 0 && !c.Bool("all") {
+		return errors.New("import paths or --all is required")
+	}
+	if err := globalInit(c); err != nil {
+		return err
+	}
+	if !setting.SaveGob {
+		return errors.New("rendering requires stored gob files, set SAVE_GOB = true in the configuration")
+	}
+
+	importPaths := []string(c.Args())
+	if c.Bool("all") {
+		var err error
+		importPaths, err = storedImportPaths()
+		if err != nil {
+			return fmt.Errorf("list stored packages: %v", err)
+		}
+	}
+
+	render := newRender()
+	failed := 0
+	for _, importPath := range importPaths {
+		if err := doc.RenderPackage(importPath, render); err != nil {
+			failed++
+			log.Error(0, "Failed to render '%s': %v", importPath, err)
+			continue
+		}
+		log.Info("Rendered '%s'", importPath)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d packages failed", failed, len(importPaths))
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/urfave/cli"
+	log "gopkg.in/clog.v1"
+
+	"github.com/unknwon/gowalker/internal/runner"
+	"github.com/unknwon/gowalker/internal/setting"
+)
+
+var Runner = cli.Command{
+	Name:  "runner",
+	Usage: "Start service to run examples",
+	Description: `Runner builds examples into complete programs and runs them
+with limits of CPU time, memory, processes, file size and wall time. Programs run
+as an unprivileged user in a read-only root without network access`,
+	Action: runRunner,
+	Flags: []cli.Flag{
+		configFlag,
+	},
+}
+
+func runRunner(c *cli.Context) error {
+	if err := initSetting(c); err != nil {
+		return err
+	}
+
+	r, err := runner.New(runner.Config{
+		GoBin:       setting.Runner.GoBin,
+		Timeout:     time.Duration(setting.Runner.Timeout) * time.Second,
+		MaxCPU:      setting.Runner.MaxCPU,
+		MaxMemory:   setting.Runner.MaxMemory,
+		MaxProcs:    setting.Runner.MaxProcs,
+		MaxFileSize: setting.Runner.MaxFileSize,
+		UID:         setting.Runner.UID,
+		GID:         setting.Runner.GID,
+	})
+	if err != nil {
+		return fmt.Errorf("new runner: %v", err)
+	}
+
+	log.Info("Runner listen: http://%s", setting.Runner.Addr)
+	if err = http.ListenAndServe(setting.Runner.Addr, r); err != nil {
+		return fmt.Errorf("start runner: %v", err)
+	}
+	return nil
+}
+
+// Sandbox is used by runner to start programs, it is not meant to be used directly.
+var Sandbox = cli.Command{
+	Name:   "sandbox",
+	Usage:  "Run program with limits",
+	Hidden: true,
+	Action: runSandbox,
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "cpu",
+			Usage: "CPU time limit in seconds",
+		},
+		cli.IntFlag{
+			Name:  "memory",
+			Usage: "Memory limit in megabytes",
+		},
+		cli.IntFlag{
+			Name:  "procs",
+			Usage: "Limit of processes of the user",
+		},
+		cli.IntFlag{
+			Name:  "file-size",
+			Usage: "File size limit in megabytes",
+		},
+		cli.IntFlag{
+			Name:  "uid",
+			Usage: "User to run program as, unchanged if not positive",
+		},
+		cli.IntFlag{
+			Name:  "gid",
+			Usage: "Group to run program as",
+		},
+	},
+}
+
+func runSandbox(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return errors.New("root directory of program is required")
+	}
+	return runner.Sandbox(runner.Config{
+		MaxCPU:      c.Int("cpu"),
+		MaxMemory:   c.Int("memory"),
+		MaxProcs:    c.Int("procs"),
+		MaxFileSize: c.Int("file-size"),
+		UID:         c.Int("uid"),
+		GID:         c.Int("gid"),
+	}, c.Args().First())
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package db
+
+import (
+	"fmt"
+)
+
+// MaxImplementors is the maximum number of implementors returned for an interface.
+const MaxImplementors = 100
+
+// Implementor represents a type that implements an interface of another package.
+type Implementor struct {
+	ID         int64
+	Interface  string `xorm:"INDEX"` // In the form of "<import path>#<name>".
+	ImportPath string `xorm:"INDEX"` // Import path of the package of type.
+	TypeName   string
+	Ptr        bool // Indicates only the pointer type implements the interface.
+}
+
+// SaveImplementors replaces implementors of the package with given ones.
+func SaveImplementors(importPath string, impls []*Implementor) (err error) {
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
+		return err
+	}
+
+	if _, err = sess.Delete(&Implementor{ImportPath: importPath}); err != nil {
+		return fmt.Errorf("delete implementors: %v", err)
+	}
+	for _, impl := range impls {
+		impl.ID = 0
+		impl.ImportPath = importPath
+		if _, err = sess.Insert(impl); err != nil {
+			return fmt.Errorf("insert implementor: %v", err)
+		}
+	}
+	return sess.Commit()
+}
+
+// GetImplementors returns types of other packages that implement the interface
+// of the package.
+func GetImplementors(importPath, name string) ([]*Implementor, error) {
+	impls := make([]*Implementor, 0, 10)
+	return impls, x.Limit(MaxImplementors).Asc("import_path").Asc("type_name").
+		Where("interface=?", importPath+"#"+name).Find(&impls)
+}
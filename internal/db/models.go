@@ -30,7 +30,8 @@ import (
 
 var x *xorm.Engine
 
-func init() {
+// Init connects to the database and syncs table schemas.
+func Init() {
 	sec := setting.Cfg.Section("database")
 	var err error
 	x, err = xorm.NewEngine("mysql", fmt.Sprintf("%s:%s@tcp(%s)/%s?charset=utf8",
@@ -43,13 +44,17 @@ func init() {
 	}
 	x.SetMapper(core.GonicMapper{})
 
-	if err = x.Sync(new(PkgInfo), new(PkgRef), new(JSFile)); err != nil {
+	if err = x.Sync(new(PkgInfo), new(PkgRef), new(JSFile), new(Implementor)); err != nil {
 		log.Fatal(2, "Failed to sync database: %v", err)
 	}
 
 	numTotalPackages, _ = x.Count(new(PkgInfo))
+}
+
+// StartRoutines starts background routines of maintenance.
+func StartRoutines() {
 	c := cron.New()
-	if err = c.AddFunc("@every 1m", RefreshNumTotalPackages); err != nil {
+	if err := c.AddFunc("@every 1m", RefreshNumTotalPackages); err != nil {
 		log.Fatal(2, "Failed to add func: %v", err)
 	} else if err = c.AddFunc("@every 1m", DistributeJSFiles); err != nil {
 		log.Fatal(2, "Failed to add func: %v", err)
@@ -51,6 +51,20 @@ type PkgInfo struct {
 	IsGoRepo    bool
 	IsGoSubrepo bool
 	IsGaeRepo   bool
+	// Indicates the package documentation has a "Deprecated:" paragraph.
+	IsDeprecated bool
+
+	// SPDX identifiers of licenses separated by ", ", "UNKNOWN" for license
+	// files that are not recognized.
+	License           string
+	LicenseConfidence float64 // Lowest confidence of classified license files.
+
+	// Module path and minimum Go version in go.mod of the package.
+	ModulePath string
+	GoVersion  string
+	// Modules providing the imports and their required versions, in form of
+	// "<import path> <module path>@<version>" separated by "|".
+	ImportVersions string `xorm:"TEXT"`
 
 	PkgVer int
 
@@ -67,6 +81,10 @@ type PkgInfo struct {
 	RefIDs string `xorm:"ref_ids LONGTEXT"`
 
 	Subdirs string `xorm:"TEXT"`
+	// Subdirectories that are roots of nested modules, separated by "|".
+	NestedModules string `xorm:"TEXT"`
+	// Indicates the package is the root of a nested module of its parent directory.
+	IsNestedModule bool `xorm:"-"`
 
 	LastViewed int64 `xorm:"NOT NULL DEFAULT 0"`
 	Created    int64
@@ -111,6 +129,21 @@ func (p *PkgInfo) LocalJSPaths() []string {
 	return paths
 }
 
+// ImportVersion returns the module providing the import and its version
+// required by go.mod of the package, or empty strings if it is unknown.
+func (p *PkgInfo) ImportVersion(importPath string) (modPath, version string) {
+	for _, v := range strings.Split(p.ImportVersions, "|") {
+		if !strings.HasPrefix(v, importPath+" ") {
+			continue
+		}
+		mod := v[len(importPath)+1:]
+		if i := strings.LastIndex(mod, "@"); i > -1 {
+			return mod[:i], mod[i+1:]
+		}
+	}
+	return "", ""
+}
+
 // CanRefresh returns true if package is available to refresh.
 func (p *PkgInfo) CanRefresh() bool {
 	return time.Now().UTC().Add(-1*setting.RefreshInterval).Unix() > p.Created
@@ -366,6 +399,20 @@ func GetSubPkgs(importPath string, dirs []string) []*PkgInfo {
 	return pinfos
 }
 
+// GetSubPkgs returns packages in subdirectories of the package, the ones that
+// are roots of nested modules are marked by IsNestedModule.
+func (p *PkgInfo) GetSubPkgs() []*PkgInfo {
+	pinfos := GetSubPkgs(p.ImportPath, strings.Split(p.Subdirs, "|"))
+	nested := make(map[string]bool)
+	for _, dir := range strings.Split(p.NestedModules, "|") {
+		nested[dir] = true
+	}
+	for _, pinfo := range pinfos {
+		pinfo.IsNestedModule = nested[pinfo.Name]
+	}
+	return pinfos
+}
+
 // GetPkgInfosByPaths returns a list of packages by given import paths.
 func GetPkgInfosByPaths(paths []string) []*PkgInfo {
 	pinfos := make([]*PkgInfo, 0, len(paths))
@@ -433,16 +480,63 @@ func GetGAERepos() ([]*PkgInfo, error) {
 	return getRepos("is_gae_repo")
 }
 
+// LicenseFilterPrefix is the prefix of search filter by license, e.g. "license:MIT".
+const LicenseFilterPrefix = "license:"
+
+// parseSearchKeyword splits the filter by license from the keyword.
+func parseSearchKeyword(keyword string) (string, string) {
+	var words []string
+	license := ""
+	for _, word := range strings.Fields(keyword) {
+		if strings.HasPrefix(strings.ToLower(word), LicenseFilterPrefix) {
+			license = word[len(LicenseFilterPrefix):]
+			continue
+		}
+		words = append(words, word)
+	}
+	return strings.Join(words, " "), license
+}
+
 // SearchPkgInfo searches package information by given keyword.
 func SearchPkgInfo(limit int, keyword string) ([]*PkgInfo, error) {
-	if len(keyword) == 0 {
+	keyword, license := parseSearchKeyword(keyword)
+	if len(keyword) == 0 && len(license) == 0 {
 		return nil, nil
 	}
 	pkgs := make([]*PkgInfo, 0, limit)
-	return pkgs, x.Limit(limit).Desc("priority").Desc("stars").Desc("views").Where("import_path like ?", "%"+keyword+"%").Find(&pkgs)
+	sess := x.Limit(limit).Asc("is_deprecated").Desc("priority").Desc("stars").Desc("views")
+	if len(keyword) > 0 {
+		sess = sess.Where("import_path like ?", "%"+keyword+"%")
+	}
+	if len(license) > 0 {
+		// Packages may have multiple licenses separated by ", ".
+		escaped := likeEscaper.Replace(license)
+		sess = sess.And("(license = ? OR license like ? escape '!' OR license like ? escape '!' OR license like ? escape '!')",
+			license, escaped+", %", "%, "+escaped, "%, "+escaped+", %")
+	}
+	return pkgs, sess.Find(&pkgs)
+}
+
+// likeEscaper escapes wildcards of LIKE patterns with "!", which must be
+// declared by ESCAPE clause of the condition.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// IteratePkgInfos calls fn for every package whose import path has given prefix,
+// all packages are iterated when prefix is empty.
+func IteratePkgInfos(prefix string, fn func(*PkgInfo) error) error {
+	sess := x.Asc("import_path")
+	if len(prefix) > 0 {
+		sess = sess.Where("import_path like ? escape '!'", likeEscaper.Replace(prefix)+"%")
+	}
+	return sess.Iterate(new(PkgInfo), func(idx int, bean interface{}) error {
+		return fn(bean.(*PkgInfo))
+	})
 }
 
 func DeletePackageByPath(importPath string) error {
+	if _, err := x.Delete(&Implementor{ImportPath: importPath}); err != nil {
+		return err
+	}
 	_, err := x.Delete(&PkgInfo{ImportPath: importPath})
 	return err
 }
@@ -20,8 +20,10 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/unknwon/com"
 	log "gopkg.in/clog.v1"
 
+	"github.com/unknwon/gowalker/internal/base"
 	"github.com/unknwon/gowalker/internal/setting"
 	"github.com/unknwon/gowalker/internal/spaces"
 )
@@ -176,3 +178,60 @@ func RecycleJSFiles() {
 		log.Error(2, "Failed to recycle JS files: %v", err)
 	}
 }
+
+// ReconcileJSFiles fixes JS file records that do not match packages or local disk:
+// records of deleted packages are removed, and generated records whose local
+// files are missing are marked as recycled.
+func ReconcileJSFiles() {
+	// Shares the status with RecycleJSFiles because both change record status.
+	if !atomic.CompareAndSwapInt32(&recycleJSFilesStatus, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&recycleJSFilesStatus, 0)
+
+	log.Trace("Routine started: ReconcileJSFiles")
+	defer log.Trace("Routine ended: ReconcileJSFiles")
+
+	var orphans []int64
+	if err := x.Where("status < ?", JSFileStatusRecycled).Iterate(new(JSFile), func(idx int, bean interface{}) error {
+		jsFile := bean.(*JSFile)
+
+		pinfo := new(PkgInfo)
+		has, err := x.ID(jsFile.PkgID).Get(pinfo)
+		if err != nil {
+			log.Error(2, "Failed to get package info by ID[%d]: %v", jsFile.PkgID, err)
+			return nil
+		} else if !has {
+			orphans = append(orphans, jsFile.ID)
+			return nil
+		}
+
+		if jsFile.Status != JSFileStatusGenerated || jsFile.Etag != pinfo.Etag {
+			return nil
+		}
+		pinfo.JSFile = jsFile
+		for _, localPath := range pinfo.LocalJSPaths() {
+			if !com.IsFile(localPath) {
+				log.Trace("ReconcileJSFiles[%d]: Local file %q is missing", jsFile.ID, localPath)
+				jsFile.Status = JSFileStatusRecycled
+				if err = SaveJSFile(jsFile); err != nil {
+					log.Error(2, "Failed to save JS file[%d]: %v", jsFile.ID, err)
+				}
+				break
+			}
+		}
+		return nil
+	}); err != nil {
+		log.Error(2, "Failed to reconcile JS files: %v", err)
+		return
+	}
+
+	if len(orphans) == 0 {
+		return
+	}
+	if _, err := x.In("id", base.Int64sToStrings(orphans)).Delete(new(JSFile)); err != nil {
+		log.Error(2, "Failed to delete orphan JS files: %v", err)
+		return
+	}
+	log.Trace("ReconcileJSFiles: Deleted %d orphan records", len(orphans))
+}
@@ -0,0 +1,601 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/unknwon/gowalker/internal/setting"
+)
+
+// MaxAPISnapshots is the maximum number of API snapshots kept for each package.
+const MaxAPISnapshots = 20
+
+var ErrAPIVersionNotFound = errors.New("API version not found")
+
+// Kinds of exported identifiers.
+const (
+	apiConst  = "const"
+	apiVar    = "var"
+	apiFunc   = "func"
+	apiType   = "type"
+	apiMethod = "method"
+	apiField  = "field"
+)
+
+// APIVersion is a version of package that has its API snapshot.
+type APIVersion struct {
+	Etag    string `json:"etag"`
+	Tag     string `json:"tag,omitempty"`
+	Created int64  `json:"created"` // Unix time in nanoseconds when the snapshot was taken.
+}
+
+// Name returns tag of the version if it has one, or etag otherwise.
+func (v *APIVersion) Name() string {
+	if len(v.Tag) > 0 {
+		return v.Tag
+	}
+	return v.Etag
+}
+
+// apiDecl is a declaration of exported identifier.
+type apiDecl struct {
+	Kind string
+	Decl string
+}
+
+// apiSnapshot contains declarations of exported identifiers of a version of package.
+type apiSnapshot struct {
+	APIVersion
+	Decls map[string]*apiDecl // Keyed by names, methods are named as "Type.Method".
+}
+
+// parseDecl parses source of single declaration.
+func parseDecl(src string) (ast.Decl, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", "package p\n"+src, 0)
+	if err != nil {
+		return nil, err
+	} else if len(file.Decls) == 0 {
+		return nil, errors.New("no declaration")
+	}
+	return file.Decls[0], nil
+}
+
+// valueDecls returns declarations of exported names in a const or var group.
+func valueDecls(src string) map[string]string {
+	decl, err := parseDecl(src)
+	if err != nil {
+		return nil
+	}
+	gdecl, ok := decl.(*ast.GenDecl)
+	if !ok {
+		return nil
+	}
+
+	decls := make(map[string]string)
+	var typ ast.Expr
+	var values []ast.Expr
+	for iota, spec := range gdecl.Specs {
+		vspec := spec.(*ast.ValueSpec)
+		// Constants without type and values repeat previous ones.
+		if gdecl.Tok == token.VAR || vspec.Type != nil || len(vspec.Values) > 0 {
+			typ, values = vspec.Type, vspec.Values
+		}
+		for i, name := range vspec.Names {
+			if !ast.IsExported(name.Name) {
+				continue
+			}
+			s := gdecl.Tok.String() + " " + name.Name
+			if typ != nil {
+				s += " " + types.ExprString(typ)
+			}
+			if i < len(values) {
+				s += " = " + types.ExprString(values[i])
+				// Values of the same expression differ with positions in group.
+				if usesIota(values[i]) {
+					s += fmt.Sprintf(" // iota = %d", iota)
+				}
+			}
+			decls[name.Name] = s
+		}
+	}
+	return decls
+}
+
+// usesIota returns true if the expression refers to iota.
+func usesIota(x ast.Expr) bool {
+	found := false
+	ast.Inspect(x, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && id.Name == "iota" {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// newAPISnapshot returns API snapshot of the package.
+func newAPISnapshot(pdoc *Package) *apiSnapshot {
+	s := &apiSnapshot{
+		APIVersion: APIVersion{
+			Etag:    pdoc.Etag,
+			Tag:     pdoc.Tag,
+			Created: time.Now().UnixNano(),
+		},
+		Decls: make(map[string]*apiDecl),
+	}
+	addValues := func(kind string, vals []*Value) {
+		for _, v := range vals {
+			for name, decl := range valueDecls(v.Decl) {
+				s.Decls[name] = &apiDecl{kind, decl}
+			}
+		}
+	}
+	addFuncs := func(funcs []*Func) {
+		for _, f := range funcs {
+			s.Decls[f.Name] = &apiDecl{apiFunc, f.Decl}
+		}
+	}
+
+	addValues(apiConst, pdoc.Consts)
+	addValues(apiVar, pdoc.Vars)
+	addFuncs(pdoc.Funcs)
+	for _, t := range pdoc.Types {
+		s.Decls[t.Name] = &apiDecl{apiType, t.Decl}
+		addValues(apiConst, t.Consts)
+		addValues(apiVar, t.Vars)
+		addFuncs(t.Funcs)
+		for _, m := range t.Methods {
+			s.Decls[t.Name+"."+m.Name] = &apiDecl{apiMethod, m.Decl}
+		}
+	}
+	return s
+}
+
+// apiSnapshotDir returns directory of API snapshots of the package.
+func apiSnapshotDir(importPath string) string {
+	return setting.DocsGobPath + importPath + "_API/"
+}
+
+func apiSnapshotPath(importPath, etag string) string {
+	return apiSnapshotDir(importPath) + strings.Replace(etag, "/", "_", -1) + ".gob"
+}
+
+// saveAPISnapshot saves API snapshot of the package and removes old ones
+// beyond MaxAPISnapshots.
+func saveAPISnapshot(pdoc *Package) error {
+	if len(pdoc.Etag) == 0 {
+		return nil
+	}
+
+	dir := apiSnapshotDir(pdoc.ImportPath)
+	os.MkdirAll(dir, os.ModePerm)
+	fw, err := os.Create(apiSnapshotPath(pdoc.ImportPath, pdoc.Etag))
+	if err != nil {
+		return fmt.Errorf("create API snapshot: %v", err)
+	}
+	defer fw.Close()
+	if err = gob.NewEncoder(fw).Encode(newAPISnapshot(pdoc)); err != nil {
+		return fmt.Errorf("encode API snapshot: %v", err)
+	}
+
+	versions, err := APIVersions(pdoc.ImportPath)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(versions)-MaxAPISnapshots; i++ {
+		os.Remove(apiSnapshotPath(pdoc.ImportPath, versions[i].Etag))
+	}
+	return nil
+}
+
+func loadAPISnapshot(fpath string) (*apiSnapshot, error) {
+	fr, err := os.Open(fpath)
+	if err != nil {
+		return nil, err
+	}
+	defer fr.Close()
+
+	s := new(apiSnapshot)
+	if err = gob.NewDecoder(fr).Decode(s); err != nil {
+		return nil, fmt.Errorf("decode API snapshot: %v", err)
+	}
+	return s, nil
+}
+
+// APIVersions returns versions of the package that have API snapshots, from
+// the oldest to the newest.
+func APIVersions(importPath string) ([]*APIVersion, error) {
+	dir := apiSnapshotDir(importPath)
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	versions := make([]*APIVersion, 0, len(fis))
+	for _, fi := range fis {
+		if fi.IsDir() || path.Ext(fi.Name()) != ".gob" {
+			continue
+		}
+		s, err := loadAPISnapshot(dir + fi.Name())
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, &s.APIVersion)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Created < versions[j].Created
+	})
+	return versions, nil
+}
+
+// APIChange is a change of exported identifier between two versions.
+type APIChange struct {
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	Change   string `json:"change"` // One of "added", "removed" and "changed".
+	Old      string `json:"old,omitempty"`
+	New      string `json:"new,omitempty"`
+	Breaking bool   `json:"breaking"`
+	Message  string `json:"message,omitempty"`
+}
+
+// APIDiff is the difference of exported identifiers between two versions of package.
+type APIDiff struct {
+	ImportPath string       `json:"import_path"`
+	From       *APIVersion  `json:"from"`
+	To         *APIVersion  `json:"to"`
+	Changes    []*APIChange `json:"changes"`
+	Breaking   bool         `json:"breaking"` // Indicates if any change is breaking.
+}
+
+// apiDiffer collects changes between two API snapshots.
+type apiDiffer struct {
+	changes []*APIChange
+}
+
+func (d *apiDiffer) add(name, kind, change, old, new string, breaking bool, msg string) {
+	d.changes = append(d.changes, &APIChange{
+		Name:     name,
+		Kind:     kind,
+		Change:   change,
+		Old:      old,
+		New:      new,
+		Breaking: breaking,
+		Message:  msg,
+	})
+}
+
+// fieldListTypes returns types of fields, names are omitted.
+func fieldListTypes(fields *ast.FieldList) string {
+	if fields == nil {
+		return ""
+	}
+	var types_ []string
+	for _, f := range fields.List {
+		n := len(f.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			types_ = append(types_, types.ExprString(f.Type))
+		}
+	}
+	return strings.Join(types_, ", ")
+}
+
+// signature returns function type without parameter names, which do not affect
+// compatibility.
+func signature(typeParams *ast.FieldList, ft *ast.FuncType) string {
+	var buf strings.Builder
+	if typeParams != nil {
+		buf.WriteString("[")
+		for i, f := range typeParams.List {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(fmt.Sprintf("%d %s", len(f.Names), types.ExprString(f.Type)))
+		}
+		buf.WriteString("]")
+	}
+	buf.WriteString("(" + fieldListTypes(ft.Params) + ")")
+	if results := fieldListTypes(ft.Results); len(results) > 0 {
+		buf.WriteString(" (" + results + ")")
+	}
+	return buf.String()
+}
+
+// funcSignature returns signature of function declaration and if its receiver
+// is a pointer.
+func funcSignature(src string) (sig string, ptrRecv bool, ok bool) {
+	decl, err := parseDecl(src)
+	if err != nil {
+		return "", false, false
+	}
+	fdecl, isFunc := decl.(*ast.FuncDecl)
+	if !isFunc {
+		return "", false, false
+	}
+	if fdecl.Recv != nil && len(fdecl.Recv.List) > 0 {
+		_, ptrRecv = fdecl.Recv.List[0].Type.(*ast.StarExpr)
+	}
+	return signature(fdecl.Type.TypeParams, fdecl.Type), ptrRecv, true
+}
+
+// typeSpec returns the type specification of type declaration.
+func typeSpec(src string) *ast.TypeSpec {
+	decl, err := parseDecl(src)
+	if err != nil {
+		return nil
+	}
+	gdecl, ok := decl.(*ast.GenDecl)
+	if !ok || gdecl.Tok != token.TYPE || len(gdecl.Specs) == 0 {
+		return nil
+	}
+	return gdecl.Specs[0].(*ast.TypeSpec)
+}
+
+// members returns exported struct fields or interface methods keyed by names,
+// values are their types. Embedded fields are named by their types.
+func members(fields *ast.FieldList, isInterface bool) map[string]string {
+	ms := make(map[string]string)
+	if fields == nil {
+		return ms
+	}
+	for _, f := range fields.List {
+		typ := types.ExprString(f.Type)
+		if ft, ok := f.Type.(*ast.FuncType); ok && isInterface {
+			typ = signature(nil, ft)
+		}
+		if len(f.Names) == 0 {
+			ms[typ] = typ
+			continue
+		}
+		for _, name := range f.Names {
+			if ast.IsExported(name.Name) {
+				ms[name.Name] = typ
+			}
+		}
+	}
+	return ms
+}
+
+// hasUnexportedMethods returns true if interface has unexported methods,
+// thus it cannot be implemented by other packages.
+func hasUnexportedMethods(it *ast.InterfaceType) bool {
+	for _, f := range it.Methods.List {
+		for _, name := range f.Names {
+			if !ast.IsExported(name.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// diffMembers compares fields of structs or methods of interfaces.
+func (d *apiDiffer) diffMembers(typeName, kind string, old, new map[string]string, addedBreaking bool) {
+	for name, oldType := range old {
+		newType, ok := new[name]
+		if !ok {
+			d.add(typeName+"."+name, kind, "removed", oldType, "", true, "")
+		} else if oldType != newType {
+			d.add(typeName+"."+name, kind, "changed", oldType, newType, true, "type changed")
+		}
+	}
+	for name, newType := range new {
+		if _, ok := old[name]; !ok {
+			msg := ""
+			if addedBreaking {
+				msg = "added to interface that can be implemented by other packages"
+			}
+			d.add(typeName+"."+name, kind, "added", "", newType, addedBreaking, msg)
+		}
+	}
+}
+
+// diffType compares declarations of the type.
+func (d *apiDiffer) diffType(name, old, new string) {
+	oldSpec, newSpec := typeSpec(old), typeSpec(new)
+	if oldSpec == nil || newSpec == nil {
+		if old != new {
+			d.add(name, apiType, "changed", old, new, true, "")
+		}
+		return
+	}
+
+	switch {
+	case oldSpec.Assign.IsValid() != newSpec.Assign.IsValid():
+		d.add(name, apiType, "changed", old, new, true, "changed between alias and defined type")
+		return
+	case fieldListTypes(oldSpec.TypeParams) != fieldListTypes(newSpec.TypeParams) ||
+		(oldSpec.TypeParams == nil) != (newSpec.TypeParams == nil):
+		d.add(name, apiType, "changed", old, new, true, "type parameters changed")
+		return
+	}
+
+	switch oldType := oldSpec.Type.(type) {
+	case *ast.StructType:
+		if newType, ok := newSpec.Type.(*ast.StructType); ok {
+			d.diffMembers(name, apiField, members(oldType.Fields, false), members(newType.Fields, false), false)
+			return
+		}
+	case *ast.InterfaceType:
+		if newType, ok := newSpec.Type.(*ast.InterfaceType); ok {
+			// Adding methods breaks implementations in other packages.
+			d.diffMembers(name, apiMethod, members(oldType.Methods, true), members(newType.Methods, true),
+				!hasUnexportedMethods(oldType))
+			return
+		}
+	}
+
+	if types.ExprString(oldSpec.Type) != types.ExprString(newSpec.Type) {
+		d.add(name, apiType, "changed", old, new, true, "underlying type changed")
+	}
+}
+
+// diffDecl compares declarations of the same name.
+func (d *apiDiffer) diffDecl(name string, old, new *apiDecl) {
+	if old.Kind != new.Kind {
+		d.add(name, new.Kind, "changed", old.Decl, new.Decl, true, "changed from "+old.Kind+" to "+new.Kind)
+		return
+	}
+
+	switch old.Kind {
+	case apiType:
+		d.diffType(name, old.Decl, new.Decl)
+	case apiFunc, apiMethod:
+		oldSig, oldPtr, oldOK := funcSignature(old.Decl)
+		newSig, newPtr, newOK := funcSignature(new.Decl)
+		switch {
+		case !oldOK || !newOK:
+			if old.Decl != new.Decl {
+				d.add(name, old.Kind, "changed", old.Decl, new.Decl, true, "")
+			}
+		case oldSig != newSig:
+			d.add(name, old.Kind, "changed", old.Decl, new.Decl, true, "signature changed")
+		case !oldPtr && newPtr:
+			d.add(name, old.Kind, "changed", old.Decl, new.Decl, true, "removed from method set of value type")
+		case oldPtr && !newPtr:
+			d.add(name, old.Kind, "changed", old.Decl, new.Decl, false, "added to method set of value type")
+		}
+	case apiVar:
+		if old.Decl == new.Decl {
+			return
+		}
+		oldType, newType := varType(old.Decl), varType(new.Decl)
+		if len(oldType) > 0 && len(newType) > 0 && oldType == newType {
+			d.add(name, apiVar, "changed", old.Decl, new.Decl, false, "initial value changed")
+		} else {
+			d.add(name, apiVar, "changed", old.Decl, new.Decl, true, "type may have changed")
+		}
+	default:
+		if old.Decl != new.Decl {
+			d.add(name, old.Kind, "changed", old.Decl, new.Decl, true, "")
+		}
+	}
+}
+
+// varType returns explicit type of variable declaration, or empty if it has none.
+func varType(decl string) string {
+	decl = strings.TrimPrefix(decl, "var ")
+	if i := strings.Index(decl, " = "); i > -1 {
+		decl = decl[:i]
+	}
+	if i := strings.Index(decl, " "); i > -1 {
+		return decl[i+1:]
+	}
+	return ""
+}
+
+// diffAPI returns changes between two API snapshots sorted by names.
+func diffAPI(from, to *apiSnapshot) []*APIChange {
+	d := new(apiDiffer)
+	for name, oldDecl := range from.Decls {
+		if newDecl, ok := to.Decls[name]; ok {
+			d.diffDecl(name, oldDecl, newDecl)
+		} else {
+			d.add(name, oldDecl.Kind, "removed", oldDecl.Decl, "", true, "")
+		}
+	}
+	for name, newDecl := range to.Decls {
+		if _, ok := from.Decls[name]; !ok {
+			d.add(name, newDecl.Kind, "added", "", newDecl.Decl, false, "")
+		}
+	}
+
+	sort.Slice(d.changes, func(i, j int) bool {
+		if d.changes[i].Name != d.changes[j].Name {
+			return d.changes[i].Name < d.changes[j].Name
+		}
+		return d.changes[i].Kind < d.changes[j].Kind
+	})
+	return d.changes
+}
+
+// findAPISnapshot returns API snapshot of the version by its etag or tag.
+func findAPISnapshot(importPath string, versions []*APIVersion, name string) (*apiSnapshot, error) {
+	for i := len(versions) - 1; i >= 0; i-- {
+		if versions[i].Etag == name || versions[i].Tag == name {
+			return loadAPISnapshot(apiSnapshotPath(importPath, versions[i].Etag))
+		}
+	}
+	return nil, ErrAPIVersionNotFound
+}
+
+// DiffAPI returns API difference of the package between two versions named by
+// etags or tags. The newest version is used if to is empty, and the version
+// before it if from is empty.
+func DiffAPI(importPath, from, to string) (*APIDiff, error) {
+	versions, err := APIVersions(importPath)
+	if err != nil {
+		return nil, err
+	} else if len(versions) == 0 {
+		return nil, ErrAPIVersionNotFound
+	}
+
+	if len(to) == 0 {
+		to = versions[len(versions)-1].Etag
+	}
+	newSnapshot, err := findAPISnapshot(importPath, versions, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(from) == 0 {
+		for i := len(versions) - 1; i > 0; i-- {
+			if versions[i].Etag == newSnapshot.Etag {
+				from = versions[i-1].Etag
+				break
+			}
+		}
+		if len(from) == 0 {
+			return nil, ErrAPIVersionNotFound
+		}
+	}
+	oldSnapshot, err := findAPISnapshot(importPath, versions, from)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &APIDiff{
+		ImportPath: importPath,
+		From:       &oldSnapshot.APIVersion,
+		To:         &newSnapshot.APIVersion,
+		Changes:    diffAPI(oldSnapshot, newSnapshot),
+	}
+	for _, c := range diff.Changes {
+		if c.Breaking {
+			diff.Breaking = true
+			break
+		}
+	}
+	return diff, nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/unknwon/gowalker/internal/db"
+	"github.com/unknwon/gowalker/internal/setting"
+)
+
+func walkAPISnapshot(t *testing.T, etag, src string) *apiSnapshot {
+	pdoc := buildTestPackage(t, "github.com/gowalker/cache", &Source{SrcName: "cache.go", SrcData: []byte(src)})
+	pdoc.Etag = etag
+	return newAPISnapshot(pdoc)
+}
+
+func TestValueDecls(t *testing.T) {
+	got := valueDecls("const (\n\tA Mode = iota\n\tB\n\tc\n\tD = 10\n)")
+	want := map[string]string{
+		"A": "const A Mode = iota // iota = 0",
+		"B": "const B Mode = iota // iota = 1",
+		"D": "const D = 10",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDiffAPI(t *testing.T) {
+	from := walkAPISnapshot(t, "v1", `package cache
+
+const DefaultSize = 10
+
+var ErrMiss = errNew("miss")
+
+type Cache struct {
+	Size int
+	Name string
+}
+
+func New(size int) *Cache { return nil }
+
+func (c Cache) Get(key string) string { return "" }
+
+func (c *Cache) Put(key, val string) {}
+
+func (c Cache) Len() int { return 0 }
+
+type Getter interface {
+	Get(key string) string
+}
+
+func Remove(key string) {}
+
+func errNew(string) error { return nil }
+`)
+	to := walkAPISnapshot(t, "v2", `package cache
+
+const DefaultSize = 10
+
+var ErrMiss = errNew("cache miss")
+
+type Cache struct {
+	Size  int64
+	Owner string
+}
+
+func New(n int, opts ...string) *Cache { return nil }
+
+func (c *Cache) Get(k string) string { return "" }
+
+func (c Cache) Put(key, val string) {}
+
+func (c Cache) Len() int { return 0 }
+
+type Getter interface {
+	Get(key string) string
+	Has(key string) bool
+}
+
+func Clear() {}
+
+func errNew(string) error { return nil }
+`)
+
+	type change struct {
+		name     string
+		change   string
+		breaking bool
+	}
+	var got []change
+	for _, c := range diffAPI(from, to) {
+		got = append(got, change{c.Name, c.Change, c.Breaking})
+	}
+	want := []change{
+		{"Cache.Get", "changed", true},
+		{"Cache.Name", "removed", true},
+		{"Cache.Owner", "added", false},
+		{"Cache.Put", "changed", false},
+		{"Cache.Size", "changed", true},
+		{"Clear", "added", false},
+		{"ErrMiss", "changed", true},
+		{"Getter.Has", "added", true},
+		{"New", "changed", true},
+		{"Remove", "removed", true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if changes := diffAPI(from, from); len(changes) > 0 {
+		t.Errorf("Same versions: got %v", changes)
+	}
+}
+
+func TestDiffAPIVersions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer func(old string) { setting.DocsGobPath = old }(setting.DocsGobPath)
+	setting.DocsGobPath = dir + "/"
+
+	importPath := "github.com/gowalker/cache"
+	if _, err = DiffAPI(importPath, "", ""); err != ErrAPIVersionNotFound {
+		t.Fatalf("No versions: got error %v, want %v", err, ErrAPIVersionNotFound)
+	}
+
+	for i, fn := range []string{"A", "B", "C"} {
+		pdoc := &Package{
+			PkgInfo: &db.PkgInfo{ImportPath: importPath, Etag: "abc/" + fn},
+			PkgDecl: &PkgDecl{File: File{Funcs: []*Func{{Name: fn, Decl: "func " + fn + "()"}}}},
+		}
+		if i == 1 {
+			pdoc.Tag = "v1.0.0"
+		}
+		if err = saveAPISnapshot(pdoc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Compares the last two versions by default.
+	diff, err := DiffAPI(importPath, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.From.Tag != "v1.0.0" || diff.To.Etag != "abc/C" || !diff.Breaking || len(diff.Changes) != 2 {
+		t.Errorf("Default versions: got from %v, to %v, changes %v", diff.From, diff.To, diff.Changes)
+	}
+
+	diff, err = DiffAPI(importPath, "abc/A", "v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.From.Etag != "abc/A" || diff.To.Etag != "abc/B" || len(diff.Changes) != 2 {
+		t.Errorf("Named versions: got from %v, to %v, changes %v", diff.From, diff.To, diff.Changes)
+	}
+
+	if _, err = DiffAPI(importPath, "v0.1.0", ""); err != ErrAPIVersionNotFound {
+		t.Errorf("Unknown version: got error %v, want %v", err, ErrAPIVersionNotFound)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"go/printer"
 	"go/scanner"
 	"go/token"
+	"go/types"
 	"math"
 	"strconv"
 )
@@ -36,6 +37,8 @@ var predeclared = map[string]int{
 	"byte":       predeclaredType,
 	"complex128": predeclaredType,
 	"complex64":  predeclaredType,
+	"any":        predeclaredType,
+	"comparable": predeclaredType,
 	"error":      predeclaredType,
 	"float32":    predeclaredType,
 	"float64":    predeclaredType,
@@ -60,6 +63,7 @@ var predeclared = map[string]int{
 
 	"append":  predeclaredFunction,
 	"cap":     predeclaredFunction,
+	"clear":   predeclaredFunction,
 	"close":   predeclaredFunction,
 	"complex": predeclaredFunction,
 	"copy":    predeclaredFunction,
@@ -67,6 +71,8 @@ var predeclared = map[string]int{
 	"imag":    predeclaredFunction,
 	"len":     predeclaredFunction,
 	"make":    predeclaredFunction,
+	"max":     predeclaredFunction,
+	"min":     predeclaredFunction,
 	"new":     predeclaredFunction,
 	"panic":   predeclaredFunction,
 	"print":   predeclaredFunction,
@@ -81,32 +87,143 @@ const (
 	CommentAnnotation
 	PackageLinkAnnotation
 	BuiltinAnnotation
+	TypeParamAnnotation
 )
 
 // annotationVisitor collects annotations.
 type annotationVisitor struct {
 	annotations []Annotation
+
+	// Type parameters in scope of current declaration, and name of
+	// the generic type or function that declares them.
+	typeParams map[string]bool
+	owner      string
+
+	// Objects resolved by type checking, nil if the package is not type-checked.
+	info *types.Info
+	pkg  *types.Package
+}
+
+// use returns the object that the identifier refers to, or nil if it is unknown.
+func (v *annotationVisitor) use(id *ast.Ident) types.Object {
+	if v.info == nil {
+		return nil
+	}
+	return v.info.Uses[id]
 }
 
 func (v *annotationVisitor) add(kind AnnotationKind, importPath string) {
 	v.annotations = append(v.annotations, Annotation{Kind: kind, ImportPath: importPath})
 }
 
+// addSelected adds annotations of the qualified identifier of imported package.
+func (v *annotationVisitor) addSelected(importPath string) {
+	v.add(PackageLinkAnnotation, importPath)
+	if importPath == "C" {
+		v.ignoreName()
+	} else {
+		v.add(ExportLinkAnnotation, importPath)
+	}
+}
+
 func (v *annotationVisitor) ignoreName() {
 	v.add(-1, "")
 }
 
+func (v *annotationVisitor) addTypeParam(name string) {
+	v.annotations = append(v.annotations, Annotation{
+		Kind:   TypeParamAnnotation,
+		Anchor: TypeParamAnchor(v.owner, name),
+	})
+}
+
+// declareTypeParams sets type parameters in scope of the declaration of owner.
+func (v *annotationVisitor) declareTypeParams(owner string, names []*ast.Ident) {
+	v.owner = owner
+	v.typeParams = make(map[string]bool, len(names))
+	for _, name := range names {
+		v.typeParams[name.Name] = true
+	}
+}
+
+// recvTypeParams returns name of the receiver base type and its type parameters.
+func recvTypeParams(recv *ast.FieldList) (string, []*ast.Ident) {
+	if recv == nil || len(recv.List) == 0 {
+		return "", nil
+	}
+
+	typ := recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	var indices []ast.Expr
+	switch t := typ.(type) {
+	case *ast.IndexExpr:
+		typ, indices = t.X, []ast.Expr{t.Index}
+	case *ast.IndexListExpr:
+		typ, indices = t.X, t.Indices
+	}
+
+	var names []*ast.Ident
+	for _, index := range indices {
+		if name, ok := index.(*ast.Ident); ok {
+			names = append(names, name)
+		}
+	}
+	if name, ok := typ.(*ast.Ident); ok {
+		return name.Name, names
+	}
+	return "", names
+}
+
+func fieldNames(fields *ast.FieldList) (names []*ast.Ident) {
+	if fields == nil {
+		return nil
+	}
+	for _, f := range fields.List {
+		names = append(names, f.Names...)
+	}
+	return names
+}
+
+// walkTypeParams walks type parameter list, names in it are declarations.
+func (v *annotationVisitor) walkTypeParams(fields *ast.FieldList) {
+	if fields == nil {
+		return
+	}
+	for _, f := range fields.List {
+		for _, name := range f.Names {
+			v.addTypeParam(name.Name)
+		}
+		ast.Walk(v, f.Type)
+	}
+}
+
 func (v *annotationVisitor) Visit(n ast.Node) ast.Visitor {
 	switch n := n.(type) {
 	case *ast.TypeSpec:
+		v.declareTypeParams(n.Name.Name, fieldNames(n.TypeParams))
 		v.ignoreName()
+		v.walkTypeParams(n.TypeParams)
 		ast.Walk(v, n.Type)
 	case *ast.FuncDecl:
 		if n.Recv != nil {
+			// Methods share type parameters of their receiver types.
+			v.declareTypeParams(recvTypeParams(n.Recv))
 			ast.Walk(v, n.Recv)
+		} else {
+			v.declareTypeParams(n.Name.Name, fieldNames(n.Type.TypeParams))
 		}
 		v.ignoreName()
 		ast.Walk(v, n.Type)
+	case *ast.FuncType:
+		v.walkTypeParams(n.TypeParams)
+		if n.Params != nil {
+			ast.Walk(v, n.Params)
+		}
+		if n.Results != nil {
+			ast.Walk(v, n.Results)
+		}
 	case *ast.Field:
 		for _ = range n.Names {
 			v.ignoreName()
@@ -123,7 +240,12 @@ func (v *annotationVisitor) Visit(n ast.Node) ast.Visitor {
 			ast.Walk(v, x)
 		}
 	case *ast.Ident:
+		obj := v.use(n)
 		switch {
+		case v.typeParams[n.Name]:
+			v.addTypeParam(n.Name)
+		case obj != nil:
+			v.annotations = append(v.annotations, objectAnnotation(v.pkg, obj, nil))
 		case n.Obj == nil && predeclared[n.Name] != notPredeclared:
 			v.add(BuiltinAnnotation, "")
 		case n.Obj != nil && ast.IsExported(n.Name):
@@ -133,15 +255,13 @@ func (v *annotationVisitor) Visit(n ast.Node) ast.Visitor {
 		}
 	case *ast.SelectorExpr:
 		if x, _ := n.X.(*ast.Ident); x != nil {
-			if obj := x.Obj; obj != nil && obj.Kind == ast.Pkg {
+			if obj, ok := v.use(x).(*types.PkgName); ok {
+				v.addSelected(obj.Imported().Path())
+				return nil
+			} else if obj := x.Obj; obj != nil && obj.Kind == ast.Pkg {
 				if spec, _ := obj.Decl.(*ast.ImportSpec); spec != nil {
 					if path, err := strconv.Unquote(spec.Path.Value); err == nil {
-						v.add(PackageLinkAnnotation, path)
-						if path == "C" {
-							v.ignoreName()
-						} else {
-							v.add(ExportLinkAnnotation, path)
-						}
+						v.addSelected(path)
 						return nil
 					}
 				}
@@ -155,8 +275,10 @@ func (v *annotationVisitor) Visit(n ast.Node) ast.Visitor {
 	return nil
 }
 
-func printDecl(decl ast.Node, fset *token.FileSet, buf []byte) (Code, []byte) {
-	v := &annotationVisitor{}
+// printDecl prints the declaration with annotations of identifiers, which are
+// resolved by info of the type-checked package pkg if info is not nil.
+func printDecl(decl ast.Node, fset *token.FileSet, buf []byte, info *types.Info, pkg *types.Package) (Code, []byte) {
+	v := &annotationVisitor{info: info, pkg: pkg}
 	ast.Walk(v, decl)
 
 	buf = buf[:0]
@@ -223,6 +345,15 @@ type Annotation struct {
 	Pos, End   int16
 	Kind       AnnotationKind
 	ImportPath string
+	// Anchor of the declaration of type parameter, or the object that
+	// is resolved by type checking if it is not named by the identifier.
+	Anchor string
+}
+
+// TypeParamAnchor returns anchor name of type parameter declared by owner,
+// which is a generic type or function.
+func TypeParamAnchor(owner, name string) string {
+	return "_tp_" + owner + "_" + name
 }
 
 type Code struct {
@@ -250,5 +381,4 @@ func commentAnnotations(src string) []Annotation {
 			annotations = append(annotations, Annotation{Kind: CommentAnnotation, Pos: int16(p), End: int16(e)})
 		}
 	}
-	return nil
 }
@@ -0,0 +1,99 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+)
+
+const genericSrc = `package list
+
+import "golang.org/x/exp/constraints"
+
+type Number interface {
+	~int | ~float64
+}
+
+type List[T any] struct {
+	items []T
+}
+
+func (l *List[T]) Push(v T) {}
+
+func Map[T, U any](s []T, f func(T) U) []U
+
+func Max[T constraints.Ordered](a, b T) T
+
+var Ints = Map[int, string]
+`
+
+// describeAnnotations returns text and kind of each annotation of declarations.
+func describeAnnotations(t *testing.T, src string) [][]string {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "list.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Resolve package names as Walker.Build does.
+	ast.NewPackage(fset, map[string]*ast.File{"list.go": file}, poorMansImporter, nil)
+
+	var decls [][]string
+	for _, decl := range file.Decls[1:] {
+		code, _ := printDecl(decl, fset, nil, nil, nil)
+		var descs []string
+		for _, a := range code.Annotations {
+			desc := fmt.Sprintf("%s:%d", code.Text[a.Pos:a.End], a.Kind)
+			if len(a.ImportPath) > 0 {
+				desc += ":" + a.ImportPath
+			}
+			if len(a.Anchor) > 0 {
+				desc += ":" + a.Anchor
+			}
+			descs = append(descs, desc)
+		}
+		decls = append(decls, descs)
+	}
+	return decls
+}
+
+func TestPrintDecl_Generics(t *testing.T) {
+	tp := func(name, owner string) string {
+		return fmt.Sprintf("%s:%d:%s", name, TypeParamAnnotation, TypeParamAnchor(owner, name))
+	}
+	builtin := func(name string) string {
+		return fmt.Sprintf("%s:%d", name, BuiltinAnnotation)
+	}
+
+	want := [][]string{
+		{builtin("int"), builtin("float64")},
+		{tp("T", "List"), builtin("any"), tp("T", "List")},
+		{fmt.Sprintf("List:%d", ExportLinkAnnotation), tp("T", "List"), tp("T", "List")},
+		{tp("T", "Map"), tp("U", "Map"), builtin("any"), tp("T", "Map"), tp("T", "Map"), tp("U", "Map"), tp("U", "Map")},
+		{
+			tp("T", "Max"),
+			fmt.Sprintf("constraints.Ordered:%d:golang.org/x/exp/constraints", ExportLinkAnnotation),
+			tp("T", "Max"), tp("T", "Max"),
+		},
+		{fmt.Sprintf("Ints:%d", AnchorAnnotation), fmt.Sprintf("Map:%d", ExportLinkAnnotation), builtin("int"), builtin("string")},
+	}
+	if got := describeAnnotations(t, genericSrc); !reflect.DeepEqual(got, want) {
+		t.Errorf("Annotations:\ngot  %v\nwant %v", got, want)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"go/ast"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Flag is a command-line flag defined by a command.
+type Flag struct {
+	Name    string
+	Type    string
+	Default string // Go expression of default value, empty if unknown.
+	Usage   string
+	URL     string // VCS URL.
+}
+
+// flagFunc describes a function of package "flag" that defines a flag.
+type flagFunc struct {
+	typ                string
+	name, value, usage int // Indexes of arguments, -1 if absent.
+}
+
+var flagFuncs = map[string]flagFunc{
+	"Bool":        {"bool", 0, 1, 2},
+	"BoolVar":     {"bool", 1, 2, 3},
+	"Duration":    {"duration", 0, 1, 2},
+	"DurationVar": {"duration", 1, 2, 3},
+	"Float64":     {"float", 0, 1, 2},
+	"Float64Var":  {"float", 1, 2, 3},
+	"Int":         {"int", 0, 1, 2},
+	"IntVar":      {"int", 1, 2, 3},
+	"Int64":       {"int", 0, 1, 2},
+	"Int64Var":    {"int", 1, 2, 3},
+	"String":      {"string", 0, 1, 2},
+	"StringVar":   {"string", 1, 2, 3},
+	"Uint":        {"uint", 0, 1, 2},
+	"UintVar":     {"uint", 1, 2, 3},
+	"Uint64":      {"uint", 0, 1, 2},
+	"Uint64Var":   {"uint", 1, 2, 3},
+	"TextVar":     {"value", 1, 2, 3},
+	"Var":         {"value", 1, -1, 2},
+	"Func":        {"func", 0, -1, 1},
+	"BoolFunc":    {"func", 0, -1, 1},
+}
+
+// flagImportName returns the name that package "flag" is imported as in the file.
+func flagImportName(file *ast.File) string {
+	for _, spec := range file.Imports {
+		if path, _ := strconv.Unquote(spec.Path.Value); path != "flag" {
+			continue
+		}
+		if spec.Name != nil {
+			return spec.Name.Name
+		}
+		return "flag"
+	}
+	return ""
+}
+
+// stringValue returns value of string literal or concatenation of literals.
+func stringValue(expr ast.Expr) (string, bool) {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		if expr.Kind == token.STRING {
+			s, err := strconv.Unquote(expr.Value)
+			return s, err == nil
+		}
+	case *ast.ParenExpr:
+		return stringValue(expr.X)
+	case *ast.BinaryExpr:
+		if expr.Op == token.ADD {
+			x, ok := stringValue(expr.X)
+			if !ok {
+				return "", false
+			}
+			y, ok := stringValue(expr.Y)
+			return x + y, ok
+		}
+	}
+	return "", false
+}
+
+// flags returns command-line flags defined by functions of package "flag"
+// in the files, sorted by names in the same way as flag.PrintDefaults.
+func (w *Walker) flags(files map[string]*ast.File) []*Flag {
+	seen := make(map[string]bool)
+	var flags []*Flag
+	for _, file := range sortedFiles(files) {
+		pkgName := flagImportName(file)
+		if len(pkgName) == 0 || pkgName == "_" {
+			continue
+		}
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			// Flags of the default set may also be defined through flag.CommandLine.
+			switch x := sel.X.(type) {
+			case *ast.Ident:
+				if x.Name != pkgName {
+					return true
+				}
+			case *ast.SelectorExpr:
+				if id, ok := x.X.(*ast.Ident); !ok || id.Name != pkgName || x.Sel.Name != "CommandLine" {
+					return true
+				}
+			default:
+				return true
+			}
+
+			fn, ok := flagFuncs[sel.Sel.Name]
+			if !ok || len(call.Args) <= fn.usage || len(call.Args) <= fn.name {
+				return true
+			}
+
+			name, ok := stringValue(call.Args[fn.name])
+			if !ok {
+				name = w.printNode(call.Args[fn.name])
+			}
+			if seen[name] {
+				return true
+			}
+			seen[name] = true
+
+			f := &Flag{
+				Name: name,
+				Type: fn.typ,
+				URL:  w.printPos(call.Pos()),
+			}
+			if fn.value >= 0 {
+				f.Default = w.printNode(call.Args[fn.value])
+			}
+			if f.Usage, ok = stringValue(call.Args[fn.usage]); !ok {
+				f.Usage = w.printNode(call.Args[fn.usage])
+			}
+			f.Usage = strings.TrimSpace(f.Usage)
+			flags = append(flags, f)
+			return true
+		})
+	}
+
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags
+}
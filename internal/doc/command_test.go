@@ -0,0 +1,78 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWalker_Flags(t *testing.T) {
+	pdoc := buildTestPackage(t, "github.com/gowalker/serve", &Source{SrcName: "main.go", BrowseUrl: "github.com/gowalker/serve/blob/master/main.go", SrcData: []byte(`// Serve serves files of a directory.
+package main
+
+import (
+	stdflag "flag"
+	"time"
+)
+
+const defaultPort = 8080
+
+var verbose bool
+
+func init() {
+	stdflag.BoolVar(&verbose, "v", false, "print verbose logs")
+}
+
+func main() {
+	addr := stdflag.String("addr", "localhost", "address to "+
+		"listen on")
+	stdflag.Int("port", defaultPort, "port to listen on")
+	stdflag.CommandLine.Duration("timeout", 5*time.Second, "  read timeout ")
+	stdflag.Func("header", "extra response header", func(string) error { return nil })
+	stdflag.String("addr", "", "duplicated")
+	stdflag.Parse()
+	_ = addr
+}
+`)})
+
+	if !pdoc.IsCmd {
+		t.Fatal("package main is not a command")
+	}
+	want := []*Flag{
+		{Name: "addr", Type: "string", Default: `"localhost"`, Usage: "address to listen on", URL: "github.com/gowalker/serve/blob/master/main.go#L18"},
+		{Name: "header", Type: "func", Usage: "extra response header", URL: "github.com/gowalker/serve/blob/master/main.go#L22"},
+		{Name: "port", Type: "int", Default: "defaultPort", Usage: "port to listen on", URL: "github.com/gowalker/serve/blob/master/main.go#L20"},
+		{Name: "timeout", Type: "duration", Default: "5 * time.Second", Usage: "read timeout", URL: "github.com/gowalker/serve/blob/master/main.go#L21"},
+		{Name: "v", Type: "bool", Default: "false", Usage: "print verbose logs", URL: "github.com/gowalker/serve/blob/master/main.go#L14"},
+	}
+	if !reflect.DeepEqual(pdoc.Flags, want) {
+		for _, f := range pdoc.Flags {
+			t.Logf("%+v", *f)
+		}
+		t.Fatal("unexpected flags")
+	}
+
+	md := string(renderMarkdown(pdoc))
+	for _, s := range []string{
+		"go install github.com/gowalker/serve@latest",
+		"| `-timeout` | `duration` | `5 * time.Second` | read timeout |",
+	} {
+		if !strings.Contains(md, s) {
+			t.Errorf("%q not found in:\n%s", s, md)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"go/doc/comment"
+	"path"
+	"strings"
+)
+
+// DocHeading is a heading in package documentation.
+type DocHeading struct {
+	ID    string
+	Title string
+}
+
+// docRenderer renders doc comments of a package, doc links are resolved to
+// anchors of the package and documentation pages of other packages.
+type docRenderer struct {
+	parser  *comment.Parser
+	printer *comment.Printer
+}
+
+// importPathName returns the package name assumed from the import path like
+// goimports does: the last element without major version suffix, e.g. "/v2"
+// and ".v2" of gopkg.in, and "go-" prefix, cut at the first character that
+// is not valid in identifiers.
+func importPathName(importPath string) string {
+	name := path.Base(importPath)
+	if isMajorSuffix(name) && strings.Contains(importPath, "/") {
+		name = path.Base(path.Dir(importPath))
+	}
+	if strings.HasPrefix(importPath, "gopkg.in/") {
+		if i := strings.LastIndex(name, ".v"); i > 0 {
+			name = name[:i]
+		}
+	}
+	name = strings.TrimPrefix(name, "go-")
+	if i := strings.IndexFunc(name, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '_')
+	}); i > -1 {
+		name = name[:i]
+	}
+	return name
+}
+
+// lookupPackage returns a function that resolves package names in doc links
+// to import paths of the package.
+func lookupPackage(pdoc *Package) func(name string) (string, bool) {
+	imports := make(map[string]string, len(pdoc.Imports))
+	for _, imp := range pdoc.Imports {
+		imports[importPathName(imp)] = imp
+	}
+	return func(name string) (string, bool) {
+		imp, ok := imports[name]
+		return imp, ok
+	}
+}
+
+func newDocRenderer(pdoc *Package) *docRenderer {
+	r := &docRenderer{
+		parser: &comment.Parser{
+			LookupPackage: lookupPackage(pdoc),
+			LookupSym: func(recv, name string) bool {
+				return hasSymbol(pdoc, recv, name)
+			},
+		},
+	}
+	r.printer = &comment.Printer{
+		// Headings in doc comments of declarations are nested under
+		// the ones of declarations.
+		HeadingLevel: 5,
+		DocLinkURL: func(link *comment.DocLink) string {
+			if len(link.ImportPath) == 0 {
+				if len(link.Recv) > 0 {
+					return memberLink(pdoc, link.Recv, link.Name)
+				}
+				return "#" + link.Name
+			}
+
+			// Members of other packages are assumed to be methods.
+			anchor := link.Name
+			if len(link.Recv) > 0 {
+				anchor = link.Recv + "_" + link.Name
+			}
+			if len(anchor) == 0 {
+				return "/" + link.ImportPath
+			}
+			return "/" + link.ImportPath + "#" + anchor
+		},
+	}
+	return r
+}
+
+// memberLink returns the link of the method or field of the type in the package.
+func memberLink(pdoc *Package, typeName, name string) string {
+	for _, t := range pdoc.Types {
+		if t.Name != typeName {
+			continue
+		}
+		for _, m := range t.Methods {
+			if m.Name == name {
+				return "#" + typeName + "_" + name
+			}
+		}
+		for _, g := range t.Promoted {
+			for _, m := range append(g.Fields, g.Methods...) {
+				if m.Name == name && len(m.Path) > 0 {
+					return m.Path
+				}
+			}
+		}
+	}
+	return "#" + FieldAnchor(typeName, name)
+}
+
+// html returns the doc comment of a declaration in HTML.
+func (r *docRenderer) html(text string) string {
+	if len(text) == 0 {
+		return ""
+	}
+	return string(r.printer.HTML(r.parser.Parse(text)))
+}
+
+// packageHTML returns the package documentation in HTML, which is rendered
+// under the title of page.
+func (r *docRenderer) packageHTML(text string) string {
+	if len(text) == 0 {
+		return ""
+	}
+	p := *r.printer
+	p.HeadingLevel = 3
+	return string(p.HTML(r.parser.Parse(text)))
+}
+
+// markdown returns the doc comment in Markdown, headings in it are nested
+// under given level.
+func (r *docRenderer) markdown(text string, headingLevel int) []byte {
+	p := *r.printer
+	p.HeadingLevel = headingLevel
+	return p.Markdown(r.parser.Parse(text))
+}
+
+// plainText returns text of the comment without links and formatting.
+func plainText(text []comment.Text) string {
+	var buf strings.Builder
+	for _, t := range text {
+		switch t := t.(type) {
+		case comment.Plain:
+			buf.WriteString(string(t))
+		case comment.Italic:
+			buf.WriteString(string(t))
+		case *comment.Link:
+			buf.WriteString(plainText(t.Text))
+		case *comment.DocLink:
+			buf.WriteString(plainText(t.Text))
+		}
+	}
+	return buf.String()
+}
+
+// headings returns headings of the doc comment as a table of contents.
+func (r *docRenderer) headings(text string) []*DocHeading {
+	var hs []*DocHeading
+	for _, b := range r.parser.Parse(text).Content {
+		if h, ok := b.(*comment.Heading); ok {
+			hs = append(hs, &DocHeading{
+				ID:    h.DefaultID(),
+				Title: plainText(h.Text),
+			})
+		}
+	}
+	return hs
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDocRenderer(t *testing.T) {
+	pdoc := buildTestPackage(t, "github.com/gowalker/cache", &Source{SrcName: "cache.go", SrcData: []byte(`// Package cache stores items, see [Cache] and [Missing].
+//
+// # Getting started
+//
+// Create a cache by [New], items are read by [Cache.Get] and sized by [Cache.Size].
+// Items are encoded by [json.Marshal], see the [design doc].
+//
+//   - Fast
+//   - Simple
+//
+// Example:
+//
+//	c := cache.New()
+//
+// [design doc]: https://example.com/design
+package cache
+
+import "encoding/json"
+
+var _ = json.Marshal
+
+type Cache struct {
+	Size int
+}
+
+func New() *Cache { return nil }
+
+func (c *Cache) Get(key string) string { return "" }
+`)})
+
+	for _, want := range []string{
+		`<p>Package cache stores items, see <a href="#Cache">Cache</a> and [Missing].`,
+		`<h3 id="hdr-Getting_started">Getting started</h3>`,
+		`<a href="#New">New</a>`,
+		`<a href="#Cache_Get">Cache.Get</a>`,
+		`<a href="#Cache.Size">Cache.Size</a>`,
+		`<a href="/encoding/json#Marshal">json.Marshal</a>`,
+		`<a href="https://example.com/design">design doc</a>`,
+		"<ul>\n<li>Fast\n<li>Simple\n</ul>",
+		"<pre>c := cache.New()\n</pre>",
+	} {
+		if !strings.Contains(pdoc.Doc, want) {
+			t.Errorf("%q not found in:\n%s", want, pdoc.Doc)
+		}
+	}
+
+	if want := []*DocHeading{{ID: "hdr-Getting_started", Title: "Getting started"}}; !reflect.DeepEqual(pdoc.Headings, want) {
+		t.Errorf("got headings %+v, want %+v", pdoc.Headings, want)
+	}
+}
+
+func TestImportPathName(t *testing.T) {
+	for importPath, want := range map[string]string{
+		"encoding/json":                "json",
+		"github.com/gowalker/cache":    "cache",
+		"github.com/gowalker/cache/v2": "cache",
+		"gopkg.in/yaml.v2":             "yaml",
+		"gopkg.in/src-d/go-git.v4":     "git",
+		"github.com/mattn/go-sqlite3":  "sqlite3",
+		"github.com/gowalker/v2":       "gowalker",
+		"v2":                           "v2",
+	} {
+		if got := importPathName(importPath); got != want {
+			t.Errorf("%q: got %q, want %q", importPath, got, want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMeta(t *testing.T) {
+	const head = `<html><head><meta name="go-import" content="gowalker.org/hello git https://github.com/gowalker/hello">`
+
+	tests := []struct {
+		name       string
+		importPath string
+		html       string
+		expMatch   map[string]string
+		expErr     string
+	}{
+		{
+			name:       "project root",
+			importPath: "gowalker.org/hello",
+			html:       head + `</head></html>`,
+			expMatch: map[string]string{
+				"importPath":  "gowalker.org/hello",
+				"repo":        "github.com/gowalker/hello",
+				"vcs":         "git",
+				"dir":         "",
+				"scheme":      "https",
+				"projectRoot": "gowalker.org/hello",
+				"projectName": "hello",
+				"projectURL":  "https://gowalker.org/hello",
+			},
+		},
+		{
+			name:       "subdirectory",
+			importPath: "gowalker.org/hello/sub",
+			html:       head + `</head></html>`,
+			expMatch: map[string]string{
+				"importPath":  "gowalker.org/hello/sub",
+				"repo":        "github.com/gowalker/hello",
+				"dir":         "/sub",
+				"projectRoot": "gowalker.org/hello",
+			},
+		},
+		{
+			name:       "trim VCS suffix",
+			importPath: "gowalker.org/hello",
+			html:       `<meta name="go-import" content="gowalker.org/hello git https://gowalker.org/r/hello.git">`,
+			expMatch: map[string]string{
+				"repo": "gowalker.org/r/hello",
+			},
+		},
+		{
+			name:       "prefix is not a path element",
+			importPath: "gowalker.org/helloworld",
+			html:       head + `</head></html>`,
+			expErr:     "<meta> not found",
+		},
+		{
+			name:       "meta in body is ignored",
+			importPath: "gowalker.org/hello",
+			html:       `<html><head></head><body><meta name="go-import" content="gowalker.org/hello git https://github.com/gowalker/hello"></body></html>`,
+			expErr:     "<meta> not found",
+		},
+		{
+			name:       "more than one match",
+			importPath: "gowalker.org/hello",
+			html:       head + `<meta name="go-import" content="gowalker.org/hello git https://github.com/gowalker/hello2"></head>`,
+			expErr:     "More than one <meta> found",
+		},
+		{
+			name:       "bad repo URL",
+			importPath: "gowalker.org/hello",
+			html:       `<meta name="go-import" content="gowalker.org/hello git github.com/gowalker/hello">`,
+			expErr:     "Bad repo URL in <meta>",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			match, err := parseMeta("https", test.importPath, strings.NewReader(test.html))
+			if test.expErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expErr) {
+					t.Fatalf("err = %v, want %q", err, test.expErr)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("parseMeta: %v", err)
+			}
+
+			for k, v := range test.expMatch {
+				if match[k] != v {
+					t.Errorf("match[%q] = %q, want %q", k, match[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestFetchMeta_FallbackToHTTP(t *testing.T) {
+	defer useFixture(t, "dynamic")()
+
+	match, err := fetchMeta("insecure.gowalker.org/x")
+	if err != nil {
+		t.Fatalf("fetchMeta: %v", err)
+	}
+	if match["projectURL"] != "http://insecure.gowalker.org/x" {
+		t.Errorf("projectURL = %q", match["projectURL"])
+	}
+}
+
+func TestGetDynamic(t *testing.T) {
+	defer useFixture(t, "dynamic")()
+
+	pdoc, err := getDynamic("gowalker.org/hello/sub", "")
+	if err != nil {
+		t.Fatalf("getDynamic: %v", err)
+	}
+
+	// Vanity import path must be kept rather than the GitHub one.
+	if pdoc.ImportPath != "gowalker.org/hello/sub" {
+		t.Errorf("ImportPath = %q", pdoc.ImportPath)
+	}
+	if pdoc.ProjectPath != "github.com/gowalker/hello" {
+		t.Errorf("ProjectPath = %q", pdoc.ProjectPath)
+	}
+	if pdoc.Synopsis != "Package sub lives in a subdirectory." {
+		t.Errorf("Synopsis = %q", pdoc.Synopsis)
+	}
+	if len(pdoc.Consts) != 1 || !strings.Contains(pdoc.Consts[0].Decl, "Answer") {
+		t.Errorf("Consts = %v", pdoc.Consts)
+	}
+}
+
+func TestGetDynamic_ProjectRootMismatch(t *testing.T) {
+	defer useFixture(t, "dynamic")()
+
+	_, err := getDynamic("mismatch.gowalker.org/a/b", "")
+	if err == nil || err.Error() != "Project root mismatch" {
+		t.Fatalf("err = %v, want %q", err, "Project root mismatch")
+	}
+}
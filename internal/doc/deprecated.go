@@ -0,0 +1,283 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"go/ast"
+	"go/doc"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/unknwon/gowalker/internal/db"
+)
+
+// MaxDeprecationImporters is the maximum number of importers checked for uses
+// of deprecated identifiers.
+const MaxDeprecationImporters = 50
+
+// Deprecation is a deprecated identifier with its note.
+type Deprecation struct {
+	Name string `json:"name"` // Methods and fields are named as "Type.Name".
+	Note string `json:"note"`
+}
+
+// deprecationNote returns text of the paragraph starts with "Deprecated: " in
+// the doc comment, or empty if there is none.
+func deprecationNote(text string) string {
+	var para []string
+	for _, line := range append(strings.Split(text, "\n"), "") {
+		line = strings.TrimSpace(line)
+		if len(line) > 0 {
+			para = append(para, line)
+			continue
+		}
+		if len(para) > 0 && strings.HasPrefix(para[0], "Deprecated: ") {
+			return strings.TrimSpace(strings.TrimPrefix(strings.Join(para, " "), "Deprecated:"))
+		}
+		para = para[:0]
+	}
+	return ""
+}
+
+// deprecations returns notes of deprecated exported identifiers of the package
+// keyed by names.
+func deprecations(pdoc *Package, dpkg *doc.Package) map[string]string {
+	deps := make(map[string]string)
+	values := func(vals []*doc.Value) {
+		for _, v := range vals {
+			groupNote := deprecationNote(v.Doc)
+			for _, spec := range v.Decl.Specs {
+				vspec := spec.(*ast.ValueSpec)
+				note := deprecationNote(vspec.Doc.Text())
+				if len(note) == 0 {
+					note = groupNote
+				}
+				for _, name := range vspec.Names {
+					if len(note) > 0 && ast.IsExported(name.Name) {
+						deps[name.Name] = note
+					}
+				}
+			}
+		}
+	}
+	funcs := func(prefix string, fs []*Func) {
+		for _, f := range fs {
+			if len(f.Deprecated) > 0 {
+				deps[prefix+f.Name] = f.Deprecated
+			}
+		}
+	}
+
+	values(dpkg.Consts)
+	values(dpkg.Vars)
+	funcs("", pdoc.Funcs)
+	for _, t := range dpkg.Types {
+		if !ast.IsExported(t.Name) {
+			continue
+		}
+		values(t.Consts)
+		values(t.Vars)
+	}
+	for _, t := range pdoc.Types {
+		if len(t.Deprecated) > 0 {
+			deps[t.Name] = t.Deprecated
+		}
+		funcs("", t.Funcs)
+		funcs(t.Name+".", t.Methods)
+		for _, f := range append(t.Fields, t.InterfaceMethods...) {
+			if len(f.Deprecated) > 0 {
+				deps[FieldAnchor(t.Name, f.Name)] = f.Deprecated
+			}
+		}
+	}
+	return deps
+}
+
+// DeprecationUse is an importer that uses deprecated identifiers of the package.
+type DeprecationUse struct {
+	ImportPath string   `json:"import_path"`
+	Names      []string `json:"names"`
+}
+
+// deprecationUses returns deprecated identifiers of the package that are used
+// by the importer. It type-checks the importer from its stored gob file to
+// resolve methods and fields.
+func deprecationUses(imp *gobImporter, importPath string, deps map[string]string, importer string) ([]string, error) {
+	pdoc, err := loadGob(importer)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*ast.File, 0, len(pdoc.Files))
+	for _, src := range pdoc.Files {
+		file, err := parser.ParseFile(imp.fset, importer+"/"+src.SrcName, src.Data(), 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	conf := types.Config{
+		Importer:    imp,
+		FakeImportC: true,
+		Error:       func(error) {},
+	}
+	info := &types.Info{
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	conf.Check(importer, imp.fset, files, info)
+
+	used := make(map[string]bool)
+	for _, obj := range info.Uses {
+		if obj.Pkg() != nil && obj.Pkg().Path() == importPath && obj.Parent() == obj.Pkg().Scope() {
+			used[obj.Name()] = true
+		}
+	}
+	for _, sel := range info.Selections {
+		obj := sel.Obj()
+		if obj.Pkg() == nil || obj.Pkg().Path() != importPath {
+			continue
+		}
+		// Promoted members are named by types that declare them.
+		recv := sel.Recv()
+		if len(sel.Index()) > 1 {
+			if v, ok := obj.(*types.Var); ok && v.IsField() {
+				recv = embeddedOwner(sel.Recv(), sel.Index())
+			} else if fn, ok := obj.(*types.Func); ok {
+				recv = fn.Type().(*types.Signature).Recv().Type()
+			}
+		}
+		if ptr, ok := recv.(*types.Pointer); ok {
+			recv = ptr.Elem()
+		}
+		if named, ok := recv.(*types.Named); ok {
+			used[named.Obj().Name()+"."+obj.Name()] = true
+		}
+	}
+
+	var names []string
+	for name := range used {
+		if _, ok := deps[name]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// embeddedOwner returns the type that declares the field or method selected
+// from t by the index path.
+func embeddedOwner(t types.Type, index []int) types.Type {
+	for _, i := range index[:len(index)-1] {
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		st, ok := t.Underlying().(*types.Struct)
+		if !ok {
+			return t
+		}
+		t = st.Field(i).Type()
+	}
+	return t
+}
+
+// DeprecationReport lists deprecated identifiers of a package and indexed
+// importers that still use them.
+type DeprecationReport struct {
+	ImportPath   string            `json:"import_path"`
+	Deprecated   string            `json:"deprecated,omitempty"` // Note of the package.
+	Deprecations []*Deprecation    `json:"deprecations"`
+	Uses         []*DeprecationUse `json:"uses"`
+}
+
+var deprecationReports = struct {
+	sync.Mutex
+	m map[string]*deprecationReport // Keyed by import path and identifier name.
+}{m: make(map[string]*deprecationReport)}
+
+type deprecationReport struct {
+	etag   string
+	report *DeprecationReport
+}
+
+// GetDeprecationReport returns deprecated identifiers of the package and its
+// importers that use them. Only given identifier is checked if name is not empty.
+// Reports are cached until the package is updated.
+func GetDeprecationReport(pinfo *db.PkgInfo, name string) (*DeprecationReport, error) {
+	key := pinfo.ImportPath + "#" + name
+	deprecationReports.Lock()
+	cached := deprecationReports.m[key]
+	deprecationReports.Unlock()
+	if cached != nil && cached.etag == pinfo.Etag {
+		return cached.report, nil
+	}
+
+	pdoc, err := loadGob(pinfo.ImportPath)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := pdoc.Deprecations
+	if len(name) > 0 {
+		deps = make(map[string]string)
+		if note, ok := pdoc.Deprecations[name]; ok {
+			deps[name] = note
+		}
+	}
+
+	report := &DeprecationReport{
+		ImportPath:   pinfo.ImportPath,
+		Deprecated:   pdoc.Deprecated,
+		Deprecations: make([]*Deprecation, 0, len(deps)),
+		Uses:         make([]*DeprecationUse, 0),
+	}
+	for name, note := range deps {
+		report.Deprecations = append(report.Deprecations, &Deprecation{name, note})
+	}
+	sort.Slice(report.Deprecations, func(i, j int) bool {
+		return report.Deprecations[i].Name < report.Deprecations[j].Name
+	})
+	if len(deps) == 0 {
+		return report, nil
+	}
+
+	imp := newGobImporter(token.NewFileSet())
+	refs := pinfo.GetRefs()
+	if len(refs) > MaxDeprecationImporters {
+		refs = refs[:MaxDeprecationImporters]
+	}
+	for _, ref := range refs {
+		names, err := deprecationUses(imp, pinfo.ImportPath, deps, ref.ImportPath)
+		if err != nil {
+			// Importers may be indexed without stored gob files.
+			continue
+		}
+		if len(names) > 0 {
+			report.Uses = append(report.Uses, &DeprecationUse{ref.ImportPath, names})
+		}
+	}
+
+	// Reports of unknown names return early, so cached keys are bounded by
+	// deprecated identifiers.
+	deprecationReports.Lock()
+	deprecationReports.m[key] = &deprecationReport{pinfo.Etag, report}
+	deprecationReports.Unlock()
+	return report, nil
+}
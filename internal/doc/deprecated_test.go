@@ -0,0 +1,176 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"go/token"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/unknwon/gowalker/internal/db"
+	"github.com/unknwon/gowalker/internal/setting"
+)
+
+func TestDeprecationNote(t *testing.T) {
+	tests := []struct {
+		doc  string
+		want string
+	}{
+		{"Open opens a file.\n", ""},
+		{"Open opens a file.\n\nDeprecated: Use OpenFile\ninstead.\n", "Use OpenFile instead."},
+		{"Deprecated: no longer used.", "no longer used."},
+		{"Open opens a file. Deprecated: not a paragraph.\n", ""},
+		{"Open opens a file.\n\n  \nDeprecated: Use New.\n\nSee New.\n", "Use New."},
+	}
+	for _, test := range tests {
+		if got := deprecationNote(test.doc); got != test.want {
+			t.Errorf("deprecationNote(%q): got %q, want %q", test.doc, got, test.want)
+		}
+	}
+}
+
+const deprecatedSrc = `// Package cache implements caches.
+//
+// Deprecated: Use github.com/gowalker/store instead.
+package cache
+
+const (
+	// Deprecated: Use Size.
+	MaxSize = 10
+	Size    = 10
+)
+
+// Deprecated: Open is slow.
+func Open() *Cache { return nil }
+
+type Cache struct {
+	// Deprecated: Use Name.
+	Key  string
+	Name string
+}
+
+// Deprecated: Use Set.
+func (c *Cache) Put(key string) {}
+
+func (c *Cache) Set(key string) {}
+
+// Entry is an entry of cache.
+//
+// Deprecated: Use Cache.
+type Entry struct{}
+`
+
+func TestWalker_Deprecations(t *testing.T) {
+	pdoc := buildTestPackage(t, "github.com/gowalker/cache", &Source{SrcName: "cache.go", SrcData: []byte(deprecatedSrc)})
+
+	if !pdoc.IsDeprecated || pdoc.Deprecated != "Use github.com/gowalker/store instead." {
+		t.Errorf("Package: got %v, %q", pdoc.IsDeprecated, pdoc.Deprecated)
+	}
+	want := map[string]string{
+		"MaxSize":   "Use Size.",
+		"Open":      "Open is slow.",
+		"Cache.Key": "Use Name.",
+		"Cache.Put": "Use Set.",
+		"Entry":     "Use Cache.",
+	}
+	if !reflect.DeepEqual(pdoc.Deprecations, want) {
+		t.Errorf("Deprecations: got %v, want %v", pdoc.Deprecations, want)
+	}
+
+	for _, tp := range pdoc.Types {
+		switch tp.Name {
+		case "Cache":
+			if len(tp.Fields) != 2 || tp.Fields[0].Deprecated != "Use Name." || tp.Fields[1].Deprecated != "" {
+				t.Errorf("Cache fields: got %v", tp.Fields)
+			}
+			if tp.Funcs[0].Deprecated == "" || tp.Methods[0].Deprecated == "" || tp.Methods[1].Deprecated != "" {
+				t.Errorf("Cache funcs or methods: got %v, %v", tp.Funcs, tp.Methods)
+			}
+		case "Entry":
+			if tp.Deprecated != "Use Cache." {
+				t.Errorf("Entry: got %q", tp.Deprecated)
+			}
+		}
+	}
+}
+
+func TestDeprecationUses(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer func(old string) { setting.DocsGobPath = old }(setting.DocsGobPath)
+	setting.DocsGobPath = dir + "/"
+
+	for _, pdoc := range []*Package{
+		{
+			PkgInfo: &db.PkgInfo{ImportPath: "github.com/gowalker/cache"},
+			PkgDecl: &PkgDecl{Files: []*Source{{SrcName: "cache.go", SrcData: []byte(deprecatedSrc)}}},
+		},
+		{
+			PkgInfo: &db.PkgInfo{ImportPath: "github.com/gowalker/app"},
+			PkgDecl: &PkgDecl{Files: []*Source{{SrcName: "app.go", SrcData: []byte(`package app
+
+import "github.com/gowalker/cache"
+
+type wrapper struct{ *cache.Cache }
+
+func run() {
+	c := cache.Open()
+	c.Set(c.Key)
+	w := wrapper{c}
+	w.Put("a")
+	_ = cache.Size
+}
+`)}}},
+		},
+	} {
+		if err = saveGob(pdoc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deps := map[string]string{
+		"MaxSize":   "Use Size.",
+		"Open":      "Open is slow.",
+		"Cache.Key": "Use Name.",
+		"Cache.Put": "Use Set.",
+	}
+	names, err := deprecationUses(newGobImporter(token.NewFileSet()), "github.com/gowalker/cache", deps, "github.com/gowalker/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Cache.Key", "Cache.Put", "Open"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got %v, want %v", names, want)
+	}
+}
+
+func TestGetDeprecationReport_NotStored(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer func(old string) { setting.DocsGobPath = old }(setting.DocsGobPath)
+	setting.DocsGobPath = dir + "/"
+
+	_, err = GetDeprecationReport(&db.PkgInfo{ImportPath: "github.com/gowalker/missing"}, "")
+	if err != ErrGobNotFound {
+		t.Errorf("got error %v, want %v", err, ErrGobNotFound)
+	}
+}
@@ -20,13 +20,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"go/doc"
 	"html/template"
-	"io"
 	"io/ioutil"
 	"os"
 	"path"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -41,6 +40,7 @@ import (
 
 var (
 	ErrFetchTimeout = errors.New("Fetch package timeout")
+	ErrGobNotFound  = errors.New("documentation of the package is not stored")
 )
 
 // A link describes the (HTML) link information for an identifier.
@@ -50,181 +50,6 @@ type Link struct {
 	Path, Name, Comment string // package path, identifier name, and comments.
 }
 
-// FormatCode highlights keywords and adds HTML links to them.
-func FormatCode(w io.Writer, code *string, links []*Link) {
-	length := len(*code) // Length of whole code.
-	if length == 0 {
-		return
-	}
-
-	*code = strings.Replace(*code, "&#34;", `"`, -1)
-	*code = strings.Replace(*code, "&#39;", `'`, -1)
-	length = len(*code)
-
-	strTag := uint8(0)      // Indicates what kind of string is chekcing.
-	isString := false       // Indicates if right now is checking string.
-	isComment := false      // Indicates if right now is checking comments.
-	isBlockComment := false // Indicates if right now is checking block comments.
-	last := 0               // Start index of the word.
-	pos := 0                // Current index.
-
-	for {
-		// Cut words.
-	CutWords:
-		for {
-			curChar := (*code)[pos] // Current check character.
-			if !com.IsLetter(curChar) {
-				if !isComment {
-					switch {
-					case curChar == '\'' || curChar == '"' || curChar == '`': // String.
-						if !isString {
-							// Set string tag.
-							strTag = curChar
-							isString = true
-						} else {
-							// CHeck if it is end of string or escaped character.
-							if ((*code)[pos-1] == '\\' && (*code)[pos-2] == '\\') || (*code)[pos-1] != '\\' {
-								// Check string tag.
-								if curChar == strTag {
-									// Handle string highlight.
-									break CutWords
-								}
-							}
-						}
-					case !isString && curChar == '/' && ((*code)[pos+1] == '/' || (*code)[pos+1] == '*'):
-						isComment = true
-					case !isString && curChar > 47 && curChar < 58: // Ends with number.
-					case !isString && curChar == '_' && pos > 0 && (*code)[pos-1] != ' ': // Underline: _
-					case !isString && (curChar != '.' || curChar == '\n'):
-						break CutWords
-					}
-				} else {
-					if isBlockComment {
-						// End of block comments.
-						if curChar == '/' && (*code)[pos-1] == '*' {
-							break CutWords
-						}
-					} else {
-						switch {
-						case curChar == '*' && (*code)[pos-1] == '/':
-							// Start of block comments.
-							isBlockComment = true
-						case curChar == '\n':
-							break CutWords
-						}
-					}
-				}
-			}
-
-			if pos == length-1 {
-				break CutWords
-			}
-			pos++
-		}
-
-		seg := (*code)[last : pos+1]
-	CheckLink:
-		switch {
-		case isComment:
-			isComment = false
-			isBlockComment = false
-			fmt.Fprintf(w, `<span class="com">%s</span>`, seg)
-		case isString:
-			isString = false
-			fmt.Fprintf(w, `<span class="str">%s</span>`, template.HTMLEscapeString(seg))
-		case seg == "\t":
-			fmt.Fprintf(w, `%s`, "    ")
-		case pos-last > 1:
-			// Check if the last word of the paragraphy.
-			l := len(seg)
-			keyword := seg
-			if !com.IsLetter(seg[l-1]) {
-				keyword = seg[:l-1]
-			} else {
-				l++
-			}
-
-			// Check keywords.
-			switch keyword {
-			case "return", "break":
-				fmt.Fprintf(w, `<span class="ret">%s</span>%s`, keyword, seg[l-1:])
-				break CheckLink
-			case "func", "range", "for", "if", "else", "type", "struct", "select", "case", "var", "const", "switch", "default", "continue":
-				fmt.Fprintf(w, `<span class="key">%s</span>%s`, keyword, seg[l-1:])
-				break CheckLink
-			case "true", "false", "nil":
-				fmt.Fprintf(w, `<span class="boo">%s</span>%s`, keyword, seg[l-1:])
-				break CheckLink
-			case "new", "append", "make", "panic", "recover", "len", "cap", "copy", "close", "delete", "defer":
-				fmt.Fprintf(w, `<span class="bui">%s</span>%s`, keyword, seg[l-1:])
-				break CheckLink
-			}
-
-			// Check links.
-			link, ok := findType(seg[:l-1], links)
-			if ok {
-				switch {
-				case len(link.Path) == 0 && len(link.Name) > 0:
-					// Exported types in current package.
-					fmt.Fprintf(w, `<a class="int" title="%s" href="#%s">%s</a>%s`,
-						link.Comment, link.Name, link.Name, seg[l-1:])
-				case len(link.Path) > 0 && len(link.Name) > 0:
-					if strings.HasPrefix(link.Path, "#") {
-						fmt.Fprintf(w, `<a class="ext" title="%s" href="%s">%s</a>%s`,
-							link.Comment, link.Path, link.Name, seg[l-1:])
-					} else {
-						fmt.Fprintf(w, `<a class="ext" title="%s" target="_blank" href="%s">%s</a>%s`,
-							link.Comment, link.Path, link.Name, seg[l-1:])
-					}
-				}
-			} else if seg[len(seg)-1] == ' ' {
-				fmt.Fprintf(w, "<span id=\"%s\">%s</span> ", seg[:len(seg)-1], seg[:len(seg)-1])
-			} else {
-				fmt.Fprintf(w, "%s", seg)
-			}
-		default:
-			fmt.Fprintf(w, "%s", seg)
-		}
-
-		last = pos + 1
-		pos++
-		// End of code.
-		if pos == length {
-			fmt.Fprintf(w, "%s", (*code)[last:])
-			return
-		}
-	}
-}
-
-func findType(name string, links []*Link) (*Link, bool) {
-	// This is for functions and types from imported packages.
-	i := strings.Index(name, ".")
-	var left, right string
-	if i > -1 {
-		left = name[:i+1]
-		right = name[i+1:]
-	}
-
-	for _, l := range links {
-		if i == -1 {
-			// Exported types and functions in current package.
-			if l.Name == name {
-				return l, true
-			}
-		} else {
-			// Functions and types from imported packages.
-			if l.Name == left {
-				if len(l.Path) > 0 {
-					return &Link{Name: name, Path: "/" + l.Path + "#" + right}, true
-				} else {
-					return &Link{Name: name, Path: "#" + right}, true
-				}
-			}
-		}
-	}
-	return nil, false
-}
-
 // getLinks returns exported objects with its jump link.
 func getLinks(pdoc *Package) []*Link {
 	links := make([]*Link, 0, len(pdoc.Types)+len(pdoc.Imports)+len(pdoc.Funcs)+10)
@@ -252,8 +77,24 @@ func getLinks(pdoc *Package) []*Link {
 		}
 	}
 
-	for _, v := range pdoc.Imports {
-		if v != "C" {
+	return append(links, importLinks(pdoc, pdoc.Imports)...)
+}
+
+// importLinks returns links of imported packages. Resolved identifiers are used
+// if the package is type-checked, package names of the rest are guessed by import paths.
+func importLinks(pdoc *Package, imports []string) []*Link {
+	links := make([]*Link, 0, len(pdoc.Idents)+len(imports))
+	resolved := make(map[string]bool)
+	for _, l := range pdoc.Idents {
+		links = append(links, l)
+		if strings.HasSuffix(l.Name, ".") {
+			resolved[l.Path] = true
+		}
+	}
+
+	for _, v := range imports {
+		// Ignore C.
+		if v != "C" && !resolved[v] {
 			links = append(links, &Link{
 				Name: path.Base(v) + ".",
 				Path: v,
@@ -264,11 +105,8 @@ func getLinks(pdoc *Package) []*Link {
 }
 
 func addFunc(f *Func, path, name string, links []*Link) {
-	var buf bytes.Buffer
 	f.FullName = name
-	f.Code = strings.Replace(f.Code, "<pre>", "&lt;pre&gt;", -1) + "}"
-	FormatCode(&buf, &f.Code, links)
-	f.Code = buf.String()
+	f.Code = formatCode(Code{f.Code + "}", f.CodeAnnotations}, links)
 }
 
 // NOTE: it can be only use for pure functions(not belong to any type), not methods.
@@ -427,19 +265,108 @@ func SavePkgDoc(docPath string, readmes map[string][]byte) {
 }
 
 type exportSearchObject struct {
-	Title string `json:"title"`
+	Title  string `json:"title"`
+	Anchor string `json:"anchor,omitempty"` // Derived from title if empty.
 }
 
-// renderDoc renders and saves the documentation file,
-// and returns the new JSFile object corresponding to this generation.
-func renderDoc(render macaron.Render, pdoc *Package, docPath string) (*db.JSFile, error) {
+// formatTypeParams highlights constraints of type parameters and adds HTML links to them.
+func formatTypeParams(tps []*TypeParam, links []*Link) {
+	for _, tp := range tps {
+		tp.FmtConstraint = formatCode(Code{Text: tp.Constraint}, links)
+	}
+}
+
+// noteSection is a group of source code notes with the same marker.
+type noteSection struct {
+	Marker string
+	Title  string
+	Notes  []*Note
+}
+
+// noteSections returns notes grouped by markers, bugs come first and the rest
+// are sorted by markers.
+func noteSections(notes map[string][]*Note) []*noteSection {
+	markers := make([]string, 0, len(notes))
+	for marker := range notes {
+		markers = append(markers, marker)
+	}
+	sort.Slice(markers, func(i, j int) bool {
+		if (markers[i] == "BUG") != (markers[j] == "BUG") {
+			return markers[i] == "BUG"
+		}
+		return markers[i] < markers[j]
+	})
+
+	sections := make([]*noteSection, len(markers))
+	for i, marker := range markers {
+		sections[i] = &noteSection{
+			Marker: marker,
+			// Same as godoc, e.g. "BUG" becomes "Bugs".
+			Title: strings.Title(strings.ToLower(marker)) + "s",
+			Notes: notes[marker],
+		}
+	}
+	return sections
+}
+
+// fileConstraintInfo is the build constraint of a Go file.
+type fileConstraintInfo struct {
+	File string
+	Expr string
+}
+
+// hasPlatformDecls returns true if any declaration of the package does not exist
+// on all platforms.
+func hasPlatformDecls(pdoc *Package) bool {
+	for _, vals := range [][]*Value{pdoc.Consts, pdoc.Vars} {
+		for _, v := range vals {
+			if len(v.Platforms) > 0 {
+				return true
+			}
+		}
+	}
+	for _, f := range pdoc.Funcs {
+		if len(f.Platforms) > 0 {
+			return true
+		}
+	}
+	for _, t := range pdoc.Types {
+		if len(t.Platforms) > 0 {
+			return true
+		}
+		for _, vals := range [][]*Value{t.Consts, t.Vars} {
+			for _, v := range vals {
+				if len(v.Platforms) > 0 {
+					return true
+				}
+			}
+		}
+		for _, fs := range [][]*Func{t.Funcs, t.Methods} {
+			for _, f := range fs {
+				if len(f.Platforms) > 0 {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// renderDocHTML renders the documentation of package with "docs/tpl" template.
+// Declarations of given package are formatted in place, so it must be only
+// rendered once.
+func renderDocHTML(render macaron.Render, pdoc *Package) ([]byte, error) {
 	data := make(map[string]interface{})
 	data["PkgFullIntro"] = pdoc.Doc
+	data["PkgHeadings"] = pdoc.Headings
+	data["IsCmd"] = pdoc.IsCmd
+	data["Flags"] = pdoc.Flags
+	data["PkgDeprecated"] = pdoc.Deprecated
 	data["IsGoRepo"] = pdoc.IsGoRepo
 
 	exports := make([]exportSearchObject, 0, 10)
 
-	var buf bytes.Buffer
+	r := newDocRenderer(pdoc)
 	links := make([]*Link, 0, len(pdoc.Types)+len(pdoc.Imports)+len(pdoc.TestImports)+
 		len(pdoc.Funcs)+10)
 	// Get all types, functions and import packages
@@ -448,16 +375,15 @@ func renderDoc(render macaron.Render, pdoc *Package, docPath string) (*db.JSFile
 			Name:    t.Name,
 			Comment: template.HTMLEscapeString(t.Doc),
 		})
-		exports = append(exports, exportSearchObject{t.Name})
+		exports = append(exports, exportSearchObject{Title: t.Name})
 	}
 
 	for _, f := range pdoc.Funcs {
-		f.Code = template.HTMLEscapeString(f.Code)
 		links = append(links, &Link{
 			Name:    f.Name,
 			Comment: template.HTMLEscapeString(f.Doc),
 		})
-		exports = append(exports, exportSearchObject{f.Name})
+		exports = append(exports, exportSearchObject{Title: f.Name})
 	}
 
 	for _, t := range pdoc.Types {
@@ -466,24 +392,20 @@ func renderDoc(render macaron.Render, pdoc *Package, docPath string) (*db.JSFile
 				Name:    f.Name,
 				Comment: template.HTMLEscapeString(f.Doc),
 			})
-			exports = append(exports, exportSearchObject{f.Name})
+			exports = append(exports, exportSearchObject{Title: f.Name})
 		}
 
 		for _, m := range t.Methods {
-			exports = append(exports, exportSearchObject{t.Name + "." + m.Name})
+			exports = append(exports, exportSearchObject{Title: t.Name + "." + m.Name})
 		}
-	}
-
-	// Ignore C.
-	for _, v := range append(pdoc.Imports, pdoc.TestImports...) {
-		if v != "C" {
-			links = append(links, &Link{
-				Name: path.Base(v) + ".",
-				Path: v,
-			})
+		for _, f := range append(t.Fields, t.InterfaceMethods...) {
+			anchor := FieldAnchor(t.Name, f.Name)
+			exports = append(exports, exportSearchObject{anchor, anchor})
 		}
 	}
 
+	links = append(links, importLinks(pdoc, append(pdoc.Imports, pdoc.TestImports...))...)
+
 	// Set exported objects type-ahead.
 	if len(exports) > 0 {
 		pdoc.IsHasExport = true
@@ -498,22 +420,15 @@ func renderDoc(render macaron.Render, pdoc *Package, docPath string) (*db.JSFile
 		pdoc.IsHasExample = true
 		data["IsHasExample"] = pdoc.IsHasExample
 		data["Examples"] = pdoc.Examples
+		data["RunExamples"] = setting.Runner.Enabled && setting.SaveGob
 	}
 
 	// Constants.
 	data["IsHasConst"] = pdoc.IsHasConst
 	data["Consts"] = pdoc.Consts
 	for i, v := range pdoc.Consts {
-		if len(v.Doc) > 0 {
-			buf.Reset()
-			doc.ToHTML(&buf, v.Doc, nil)
-			v.Doc = buf.String()
-		}
-		buf.Reset()
-		v.Decl = template.HTMLEscapeString(v.Decl)
-		v.Decl = strings.Replace(v.Decl, "&#34;", "\"", -1)
-		FormatCode(&buf, &v.Decl, links)
-		v.FmtDecl = buf.String()
+		v.Doc = r.html(v.Doc)
+		v.FmtDecl = formatCode(Code{v.Decl, v.Annotations}, links)
 		pdoc.Consts[i] = v
 	}
 
@@ -521,14 +436,8 @@ func renderDoc(render macaron.Render, pdoc *Package, docPath string) (*db.JSFile
 	data["IsHasVar"] = pdoc.IsHasVar
 	data["Vars"] = pdoc.Vars
 	for i, v := range pdoc.Vars {
-		if len(v.Doc) > 0 {
-			buf.Reset()
-			doc.ToHTML(&buf, v.Doc, nil)
-			v.Doc = buf.String()
-		}
-		buf.Reset()
-		FormatCode(&buf, &v.Decl, links)
-		v.FmtDecl = buf.String()
+		v.Doc = r.html(v.Doc)
+		v.FmtDecl = formatCode(Code{v.Decl, v.Annotations}, links)
 		pdoc.Vars[i] = v
 	}
 
@@ -549,6 +458,17 @@ func renderDoc(render macaron.Render, pdoc *Package, docPath string) (*db.JSFile
 			viewFilePath = strings.Replace(viewFilePath, "blob/", "tree/", 1)
 		}
 		data["ViewFilePath"] = viewFilePath
+		data["SourceViewer"] = setting.SaveGob
+	}
+
+	// Notes.
+	if len(pdoc.Notes) > 0 {
+		for _, notes := range pdoc.Notes {
+			for _, n := range notes {
+				n.Body = r.html(n.Body)
+			}
+		}
+		data["NoteSections"] = noteSections(pdoc.Notes)
 	}
 
 	var err error
@@ -556,14 +476,9 @@ func renderDoc(render macaron.Render, pdoc *Package, docPath string) (*db.JSFile
 
 	data["Funcs"] = pdoc.Funcs
 	for i, f := range pdoc.Funcs {
-		if len(f.Doc) > 0 {
-			buf.Reset()
-			doc.ToHTML(&buf, f.Doc, nil)
-			f.Doc = buf.String()
-		}
-		buf.Reset()
-		FormatCode(&buf, &f.Decl, links)
-		f.FmtDecl = buf.String() + " {"
+		f.Doc = r.html(f.Doc)
+		f.FmtDecl = formatCode(Code{f.Decl, f.Annotations}, links) + " {"
+		formatTypeParams(f.TypeParams, links)
 		if exs := getExamples(pdoc, "", f.Name); len(exs) > 0 {
 			f.Examples = exs
 		}
@@ -571,68 +486,43 @@ func renderDoc(render macaron.Render, pdoc *Package, docPath string) (*db.JSFile
 	}
 
 	data["Types"] = pdoc.Types
+	// Implementors in other packages are indexed by type checking.
+	data["Implementors"] = setting.TypeCheck
 	for i, t := range pdoc.Types {
 		for j, v := range t.Consts {
-			if len(v.Doc) > 0 {
-				buf.Reset()
-				doc.ToHTML(&buf, v.Doc, nil)
-				v.Doc = buf.String()
-			}
-			buf.Reset()
-			v.Decl = template.HTMLEscapeString(v.Decl)
-			v.Decl = strings.Replace(v.Decl, "&#34;", "\"", -1)
-			FormatCode(&buf, &v.Decl, links)
-			v.FmtDecl = buf.String()
+			v.Doc = r.html(v.Doc)
+			v.FmtDecl = formatCode(Code{v.Decl, v.Annotations}, links)
 			t.Consts[j] = v
 		}
 		for j, v := range t.Vars {
-			if len(v.Doc) > 0 {
-				buf.Reset()
-				doc.ToHTML(&buf, v.Doc, nil)
-				v.Doc = buf.String()
-			}
-			buf.Reset()
-			FormatCode(&buf, &v.Decl, links)
-			v.FmtDecl = buf.String()
+			v.Doc = r.html(v.Doc)
+			v.FmtDecl = formatCode(Code{v.Decl, v.Annotations}, links)
 			t.Vars[j] = v
 		}
 
 		for j, f := range t.Funcs {
-			if len(f.Doc) > 0 {
-				buf.Reset()
-				doc.ToHTML(&buf, f.Doc, nil)
-				f.Doc = buf.String()
-			}
-			buf.Reset()
-			FormatCode(&buf, &f.Decl, links)
-			f.FmtDecl = buf.String() + " {"
+			f.Doc = r.html(f.Doc)
+			f.FmtDecl = formatCode(Code{f.Decl, f.Annotations}, links) + " {"
+			formatTypeParams(f.TypeParams, links)
 			if exs := getExamples(pdoc, "", f.Name); len(exs) > 0 {
 				f.Examples = exs
 			}
 			t.Funcs[j] = f
 		}
 		for j, m := range t.Methods {
-			if len(m.Doc) > 0 {
-				buf.Reset()
-				doc.ToHTML(&buf, m.Doc, nil)
-				m.Doc = buf.String()
-			}
-			buf.Reset()
-			FormatCode(&buf, &m.Decl, links)
-			m.FmtDecl = buf.String() + " {"
+			m.Doc = r.html(m.Doc)
+			m.FmtDecl = formatCode(Code{m.Decl, m.Annotations}, links) + " {"
 			if exs := getExamples(pdoc, t.Name, m.Name); len(exs) > 0 {
 				m.Examples = exs
 			}
 			t.Methods[j] = m
 		}
-		if len(t.Doc) > 0 {
-			buf.Reset()
-			doc.ToHTML(&buf, t.Doc, nil)
-			t.Doc = buf.String()
+		t.Doc = r.html(t.Doc)
+		for _, f := range append(t.Fields, t.InterfaceMethods...) {
+			f.Doc = r.html(f.Doc)
 		}
-		buf.Reset()
-		FormatCode(&buf, &t.Decl, links)
-		t.FmtDecl = buf.String()
+		t.FmtDecl = formatCode(Code{t.Decl, t.Annotations}, links)
+		formatTypeParams(t.TypeParams, links)
 		if exs := getExamples(pdoc, "", t.Name); len(exs) > 0 {
 			t.Examples = exs
 		}
@@ -645,9 +535,27 @@ func renderDoc(render macaron.Render, pdoc *Package, docPath string) (*db.JSFile
 	})
 
 	for _, e := range pdoc.Examples {
-		buf.Reset()
-		FormatCode(&buf, &e.Code, links)
-		e.Code = buf.String()
+		e.Code = formatCode(Code{Text: e.Code}, links)
+	}
+
+	// Build constraints.
+	if len(pdoc.Constraints) > 0 {
+		constraints := make([]*fileConstraintInfo, 0, len(pdoc.Constraints))
+		for name, expr := range pdoc.Constraints {
+			constraints = append(constraints, &fileConstraintInfo{name, expr})
+		}
+		sort.Slice(constraints, func(i, j int) bool {
+			return constraints[i].File < constraints[j].File
+		})
+		data["Constraints"] = constraints
+		if setting.SaveGob {
+			data["BuildTags"] = customBuildTags(pdoc.Constraints)
+		}
+	}
+
+	// Only offer platform selector when declarations differ between platforms.
+	if hasPlatformDecls(pdoc) {
+		data["Platforms"] = pdoc.Platforms
 	}
 
 	data["ProjectPath"] = pdoc.ProjectPath
@@ -662,6 +570,16 @@ func renderDoc(render macaron.Render, pdoc *Package, docPath string) (*db.JSFile
 	if err != nil {
 		return nil, fmt.Errorf("rendering HTML: %v", err)
 	}
+	return result, nil
+}
+
+// renderDoc renders and saves the documentation file,
+// and returns the new JSFile object corresponding to this generation.
+func renderDoc(render macaron.Render, pdoc *Package, docPath string) (*db.JSFile, error) {
+	result, err := renderDocHTML(render, pdoc)
+	if err != nil {
+		return nil, err
+	}
 
 	numExtraFiles := SaveDocPage(docPath, result)
 	if numExtraFiles == -1 {
@@ -669,7 +587,6 @@ func renderDoc(render macaron.Render, pdoc *Package, docPath string) (*db.JSFile
 	}
 	SavePkgDoc(pdoc.ImportPath, pdoc.Readme)
 
-	data["UtcTime"] = time.Unix(pdoc.Created, 0).UTC()
 	return &db.JSFile{
 		Etag:          pdoc.Etag,
 		Status:        db.JSFileStatusGenerated,
@@ -677,6 +594,78 @@ func renderDoc(render macaron.Render, pdoc *Package, docPath string) (*db.JSFile
 	}, nil
 }
 
+func gobPath(importPath string) string {
+	return setting.DocsGobPath + importPath + ".gob"
+}
+
+// loadGob decodes package from its stored gob file.
+func loadGob(importPath string) (*Package, error) {
+	fr, err := os.Open(gobPath(importPath))
+	if os.IsNotExist(err) {
+		return nil, ErrGobNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("read gob: %v", err)
+	}
+	defer fr.Close()
+
+	pdoc := new(Package)
+	if err = gob.NewDecoder(fr).Decode(pdoc); err != nil {
+		return nil, fmt.Errorf("decode gob: %v", err)
+	}
+	return pdoc, nil
+}
+
+// saveGob encodes package into its gob file.
+func saveGob(pdoc *Package) error {
+	gobPath := gobPath(pdoc.ImportPath)
+	os.MkdirAll(path.Dir(gobPath), os.ModePerm)
+	fw, err := os.Create(gobPath)
+	if err != nil {
+		return fmt.Errorf("create gob: %v", err)
+	}
+	defer fw.Close()
+	if err = gob.NewEncoder(fw).Encode(pdoc); err != nil {
+		return fmt.Errorf("encode gob: %v", err)
+	}
+	return nil
+}
+
+// PackageNotes returns source code notes of given package grouped by markers,
+// the package must have its gob file stored.
+func PackageNotes(importPath string) (map[string][]*Note, error) {
+	pdoc, err := loadGob(importPath)
+	if err != nil {
+		return nil, err
+	}
+	return pdoc.Notes, nil
+}
+
+// RenderPackage regenerates documentation of given package from its stored
+// gob file without fetching from VCS.
+func RenderPackage(importPath string, render macaron.Render) error {
+	pinfo, err := db.GetPkgInfo(importPath)
+	if err != nil && (err != db.ErrPackageVersionTooOld || pinfo == nil) {
+		return fmt.Errorf("get package info: %v", err)
+	}
+
+	pdoc, err := loadGob(importPath)
+	if err != nil {
+		return err
+	} else if len(pinfo.Etag) > 0 && pinfo.Etag != pdoc.Etag {
+		return fmt.Errorf("stored gob is outdated: %s != %s", pdoc.Etag, pinfo.Etag)
+	}
+
+	jsFile, err := renderDoc(render, pdoc, importPath)
+	if err != nil {
+		return fmt.Errorf("render doc: %v", err)
+	}
+	jsFile.PkgID = pinfo.ID
+	if err = db.SaveJSFile(jsFile); err != nil {
+		return fmt.Errorf("SaveJSFile[%s]: %v", importPath, err)
+	}
+	return nil
+}
+
 type requestType int
 
 const (
@@ -692,17 +681,11 @@ func CheckPackage(importPath string, render macaron.Render, rt requestType) (*db
 	pinfo, err := db.GetPkgInfo(importPath)
 	if rt != RequestTypeRefresh {
 		if err == nil {
-			gobPath := setting.DocsGobPath + importPath + ".gob"
-			if !setting.ProdMode && com.IsFile(gobPath) {
-				pdoc := new(Package)
-				fr, err := os.Open(gobPath)
+			if !setting.ProdMode && com.IsFile(gobPath(importPath)) {
+				pdoc, err := loadGob(importPath)
 				if err != nil {
-					return nil, fmt.Errorf("read gob: %v", err)
-				} else if err = gob.NewDecoder(fr).Decode(pdoc); err != nil {
-					fr.Close()
-					return nil, fmt.Errorf("decode gob: %v", err)
+					return nil, err
 				}
-				fr.Close()
 
 				_, err = renderDoc(render, pdoc, importPath)
 				if err != nil {
@@ -761,16 +744,15 @@ func CheckPackage(importPath string, render macaron.Render, rt requestType) (*db
 		return nil, fmt.Errorf("check package: %v", err)
 	}
 
-	if !setting.ProdMode {
-		gobPath := setting.DocsGobPath + importPath + ".gob"
-		os.MkdirAll(path.Dir(gobPath), os.ModePerm)
-		fw, err := os.Create(gobPath)
-		if err != nil {
-			return nil, fmt.Errorf("create gob: %v", err)
+	// Features that work from stored sources, e.g. running examples, source
+	// viewer, views of build tags and docsets, are offered only if gob files
+	// are saved.
+	if setting.SaveGob {
+		if err = saveGob(pdoc); err != nil {
+			return nil, err
 		}
-		defer fw.Close()
-		if err = gob.NewEncoder(fw).Encode(pdoc); err != nil {
-			return nil, fmt.Errorf("encode gob: %v", err)
+		if err = saveAPISnapshot(pdoc); err != nil {
+			log.Error(2, "saveAPISnapshot %q: %v", pdoc.ImportPath, err)
 		}
 	}
 
@@ -799,5 +781,11 @@ func CheckPackage(importPath string, render macaron.Render, rt requestType) (*db
 	}
 	pdoc.JSFile = jsFile
 
+	if setting.TypeCheck {
+		if err = db.SaveImplementors(pdoc.ImportPath, pdoc.Implementors); err != nil {
+			return nil, fmt.Errorf("SaveImplementors[%s]: %v", importPath, err)
+		}
+	}
+
 	return pdoc.PkgInfo, nil
 }
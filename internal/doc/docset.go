@@ -0,0 +1,338 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/unknwon/com"
+	"gopkg.in/macaron.v1"
+
+	"github.com/unknwon/gowalker/internal/db"
+	"github.com/unknwon/gowalker/internal/setting"
+)
+
+// Entry types of docset search index.
+const (
+	DocsetPackage  = "Package"
+	DocsetType     = "Type"
+	DocsetFunction = "Function"
+	DocsetMethod   = "Method"
+	DocsetConstant = "Constant"
+	DocsetVariable = "Variable"
+)
+
+// docsetEntry is a record of docset search index.
+type docsetEntry struct {
+	Name string
+	Type string
+	Path string // Relative to Documents directory.
+}
+
+// dashAnchorName returns name of Dash TOC anchor for the entry.
+func dashAnchorName(typ, name string) string {
+	return "//apple_ref/cpp/" + typ + "/" + url.PathEscape(name)
+}
+
+// insertDashAnchor inserts Dash TOC anchor before the element that is found
+// first by given targets in order. It returns false if none of them exists.
+func insertDashAnchor(html []byte, anchor string, targets ...string) ([]byte, bool) {
+	i := -1
+	for _, target := range targets {
+		if i = bytes.Index(html, []byte(target)); i > -1 {
+			break
+		}
+	}
+	if i == -1 {
+		return html, false
+	}
+	i = bytes.LastIndexByte(html[:i+1], '<')
+
+	buf := make([]byte, 0, len(html)+len(anchor)+40)
+	buf = append(buf, html[:i]...)
+	buf = append(buf, `<a name="`+anchor+`" class="dashAnchor"></a>`...)
+	return append(buf, html[i:]...), true
+}
+
+func headingID(id string) string {
+	return `<h4 id="` + id + `"`
+}
+
+// addDashAnchors inserts Dash TOC anchors to rendered documentation of the
+// package, and collects corresponding search index entries.
+func (e *siteExporter) addDashAnchors(importPath string, pdoc *Package, html []byte) []byte {
+	page := importPath + "/index.html"
+	e.entries = append(e.entries, &docsetEntry{importPath, DocsetPackage, page})
+
+	add := func(typ, name string, targets ...string) {
+		anchor := dashAnchorName(typ, name)
+		var ok bool
+		if html, ok = insertDashAnchor(html, anchor, targets...); ok {
+			e.entries = append(e.entries, &docsetEntry{name, typ, page + "#" + anchor})
+		}
+	}
+	// Names of values are only identified by spans of highlighted declarations.
+	addValues := func(typ string, vals []*Value, fallback string) {
+		for _, v := range vals {
+			for _, name := range v.Names {
+				add(typ, name, `<span id="`+name+`"`, fallback)
+			}
+		}
+	}
+
+	addValues(DocsetConstant, pdoc.Consts, ` id="_constants"`)
+	addValues(DocsetVariable, pdoc.Vars, ` id="_variables"`)
+	for _, f := range pdoc.Funcs {
+		add(DocsetFunction, f.Name, headingID(f.Name))
+	}
+	for _, t := range pdoc.Types {
+		add(DocsetType, t.Name, headingID(t.Name))
+		addValues(DocsetConstant, t.Consts, headingID(t.Name))
+		addValues(DocsetVariable, t.Vars, headingID(t.Name))
+		for _, f := range t.Funcs {
+			add(DocsetFunction, f.Name, headingID(f.Name))
+		}
+		for _, m := range t.Methods {
+			add(DocsetMethod, t.Name+"."+m.Name, headingID(m.FullName))
+		}
+	}
+	return html
+}
+
+const infoPlist = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>CFBundleIdentifier</key>
+	<string>%[1]s</string>
+	<key>CFBundleName</key>
+	<string>%[1]s</string>
+	<key>DocSetPlatformFamily</key>
+	<string>go</string>
+	<key>dashIndexFilePath</key>
+	<string>index.html</string>
+	<key>isDashDocset</key>
+	<true/>
+	<key>isJavaScriptEnabled</key>
+	<true/>
+</dict>
+</plist>
+`
+
+// MaxDocsetPackages is the maximum number of packages in a docset archive
+// that is generated on demand.
+const MaxDocsetPackages = 200
+
+var ErrDocsetTooLarge = errors.New("Too many packages for docset")
+
+// docsetSema limits the number of docset archives being generated concurrently.
+var docsetSema = make(chan struct{}, 2)
+
+// subpackages returns indexed subpackages of the package that have gob files
+// stored. It fails with ErrDocsetTooLarge when there are more than limit of
+// them, or never if limit is not positive.
+func subpackages(importPath string, limit int) ([]*db.PkgInfo, error) {
+	var pinfos []*db.PkgInfo
+	err := db.IteratePkgInfos(importPath+"/", func(pinfo *db.PkgInfo) error {
+		if !com.IsFile(gobPath(pinfo.ImportPath)) {
+			return nil
+		} else if limit > 0 && len(pinfos) >= limit {
+			return ErrDocsetTooLarge
+		}
+		pinfos = append(pinfos, pinfo)
+		return nil
+	})
+	return pinfos, err
+}
+
+// PackageTree returns import paths of the package and its indexed subpackages
+// that have gob files stored.
+func PackageTree(importPath string) ([]string, error) {
+	pinfos, err := subpackages(importPath, 0)
+	if err != nil {
+		return nil, err
+	}
+	importPaths := []string{importPath}
+	for _, pinfo := range pinfos {
+		importPaths = append(importPaths, pinfo.ImportPath)
+	}
+	return importPaths, nil
+}
+
+// docsetArchivePrefix returns prefix of paths of cached docset archives of the package tree.
+func docsetArchivePrefix(importPath string) string {
+	return setting.DocsGobPath + importPath + ".docset."
+}
+
+// DocsetArchive returns path of the gzipped tarball of docset of the package
+// and its subpackages. The archive is cached until any package in the tree
+// changes, and archives of previous versions are removed.
+func DocsetArchive(render macaron.Render, pinfo *db.PkgInfo) (string, error) {
+	pinfos, err := subpackages(pinfo.ImportPath, MaxDocsetPackages-1)
+	if err != nil {
+		return "", err
+	}
+	pinfos = append([]*db.PkgInfo{pinfo}, pinfos...)
+
+	h := sha1.New()
+	importPaths := make([]string, len(pinfos))
+	for i := range pinfos {
+		importPaths[i] = pinfos[i].ImportPath
+		fmt.Fprintf(h, "%s %s\n", pinfos[i].ImportPath, pinfos[i].Etag)
+	}
+	prefix := docsetArchivePrefix(pinfo.ImportPath)
+	archivePath := fmt.Sprintf("%s%x.tgz", prefix, h.Sum(nil))
+	if com.IsFile(archivePath) {
+		return archivePath, nil
+	}
+
+	docsetSema <- struct{}{}
+	defer func() { <-docsetSema }()
+	// It may have been generated while waiting.
+	if com.IsFile(archivePath) {
+		return archivePath, nil
+	}
+
+	tmpDir, err := ioutil.TempDir("", "gowalker-docset")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	name := path.Base(pinfo.ImportPath)
+	docsetPath := filepath.Join(tmpDir, name+".docset")
+	if err = ExportDocset(render, name, importPaths, "public", docsetPath); err != nil {
+		return "", fmt.Errorf("generate docset: %v", err)
+	}
+
+	// Archive is renamed when completed so that partial files are never served.
+	dir := path.Dir(archivePath)
+	os.MkdirAll(dir, os.ModePerm)
+	f, err := ioutil.TempFile(dir, path.Base(prefix)+"*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	err = ArchiveDocset(f, docsetPath)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("archive docset: %v", err)
+	} else if err = os.Rename(f.Name(), archivePath); err != nil {
+		return "", err
+	}
+
+	removeStaleArchives(prefix, archivePath)
+	return archivePath, nil
+}
+
+// removeStaleArchives removes docset archives that have given path prefix
+// except the current one.
+func removeStaleArchives(prefix, current string) {
+	infos, err := ioutil.ReadDir(path.Dir(prefix))
+	if err != nil {
+		return
+	}
+	base := path.Base(prefix)
+	for _, info := range infos {
+		name := info.Name()
+		if strings.HasPrefix(name, base) && strings.HasSuffix(name, ".tgz") &&
+			path.Join(path.Dir(prefix), name) != path.Clean(current) {
+			os.Remove(path.Join(path.Dir(prefix), name))
+		}
+	}
+}
+
+// ExportDocset writes documentation of given packages as a Dash docset bundle
+// named by name to docsetPath, which must not exist.
+// Packages must have their gob files stored.
+func ExportDocset(render macaron.Render, name string, importPaths []string, publicDir, docsetPath string) error {
+	resDir := filepath.Join(docsetPath, "Contents", "Resources")
+	e := newSiteExporter(render, importPaths, filepath.Join(resDir, "Documents"))
+	e.docset = true
+	if err := e.export(importPaths, publicDir); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(name))
+	if err := ioutil.WriteFile(filepath.Join(docsetPath, "Contents", "Info.plist"),
+		[]byte(fmt.Sprintf(infoPlist, buf.String())), 0644); err != nil {
+		return fmt.Errorf("save Info.plist: %v", err)
+	}
+
+	if err := saveSearchIndex(filepath.Join(resDir, "docSet.dsidx"), e.entries); err != nil {
+		return fmt.Errorf("save search index: %v", err)
+	}
+	return nil
+}
+
+// ArchiveDocset writes docset bundle at docsetPath to w as a gzipped tarball.
+func ArchiveDocset(w io.Writer, docsetPath string) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	parent := filepath.Dir(docsetPath)
+	if err := filepath.Walk(docsetPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(parent, path)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(name)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err = tw.WriteHeader(hdr); err != nil || info.IsDir() {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
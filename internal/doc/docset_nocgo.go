@@ -0,0 +1,30 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build !cgo
+// +build !cgo
+
+package doc
+
+import (
+	"errors"
+)
+
+// DocsetSupported indicates whether docsets can be generated, which needs cgo
+// for the SQLite search index.
+const DocsetSupported = false
+
+func saveSearchIndex(dbPath string, entries []*docsetEntry) error {
+	return errors.New("docset is not supported by binaries built without cgo")
+}
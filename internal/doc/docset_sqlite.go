@@ -0,0 +1,56 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build cgo
+// +build cgo
+
+package doc
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// DocsetSupported indicates whether docsets can be generated, which needs cgo
+// for the SQLite search index.
+const DocsetSupported = true
+
+// saveSearchIndex creates the SQLite search index of docset.
+func saveSearchIndex(dbPath string, entries []*docsetEntry) error {
+	x, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return err
+	}
+	defer x.Close()
+
+	if _, err = x.Exec(`CREATE TABLE searchIndex(id INTEGER PRIMARY KEY, name TEXT, type TEXT, path TEXT);
+CREATE UNIQUE INDEX anchor ON searchIndex (name, type, path);`); err != nil {
+		return fmt.Errorf("create table: %v", err)
+	}
+
+	tx, err := x.Begin()
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if _, err = tx.Exec("INSERT OR IGNORE INTO searchIndex(name, type, path) VALUES (?, ?, ?)",
+			entry.Name, entry.Type, entry.Path); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("insert entry: %v", err)
+		}
+	}
+	return tx.Commit()
+}
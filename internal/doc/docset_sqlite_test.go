@@ -0,0 +1,55 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build cgo
+// +build cgo
+
+package doc
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveSearchIndex(t *testing.T) {
+	dir, err := ioutil.TempDir("", "docset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	dbPath := filepath.Join(dir, "docSet.dsidx")
+	entry := &docsetEntry{"Greet", DocsetFunction, "hello/index.html#Greet"}
+	if err = saveSearchIndex(dbPath, []*docsetEntry{entry, entry}); err != nil {
+		t.Fatalf("saveSearchIndex: %v", err)
+	}
+
+	x, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer x.Close()
+
+	var name, typ, path string
+	var count int
+	if err = x.QueryRow("SELECT name, type, path, COUNT(*) FROM searchIndex").Scan(&name, &typ, &path, &count); err != nil {
+		t.Fatal(err)
+	}
+	if name != "Greet" || typ != DocsetFunction || path != entry.Path || count != 1 {
+		t.Errorf("Got (%q, %q, %q, %d)", name, typ, path, count)
+	}
+}
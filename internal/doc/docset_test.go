@@ -0,0 +1,97 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/unknwon/com"
+)
+
+func TestSiteExporter_AddDashAnchors(t *testing.T) {
+	pdoc := &Package{
+		PkgDecl: &PkgDecl{
+			File: File{
+				Consts: []*Value{{Names: []string{"DefaultGreeting"}}},
+				Types: []*Type{{
+					Name:    "Greeter",
+					Methods: []*Func{{Name: "Greet", FullName: "Greeter_Greet"}},
+				}},
+			},
+		},
+	}
+	html := `<h2 id="_constants">Constants</h2><pre>const <span id="DefaultGreeting">DefaultGreeting</span> = "Hello"</pre>` +
+		`<h4 id="Greeter">type Greeter</h4><h4 id="Greeter_Greet">func Greet</h4>`
+
+	e := &siteExporter{docset: true}
+	got := string(e.addDashAnchors("github.com/gowalker/hello", pdoc, []byte(html)))
+
+	for _, want := range []string{
+		`<pre>const <a name="//apple_ref/cpp/Constant/DefaultGreeting" class="dashAnchor"></a><span id="DefaultGreeting">`,
+		`<a name="//apple_ref/cpp/Type/Greeter" class="dashAnchor"></a><h4 id="Greeter">`,
+		`<a name="//apple_ref/cpp/Method/Greeter.Greet" class="dashAnchor"></a><h4 id="Greeter_Greet">`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Missing %q in %q", want, got)
+		}
+	}
+
+	wantEntries := []docsetEntry{
+		{"github.com/gowalker/hello", DocsetPackage, "github.com/gowalker/hello/index.html"},
+		{"DefaultGreeting", DocsetConstant, "github.com/gowalker/hello/index.html#//apple_ref/cpp/Constant/DefaultGreeting"},
+		{"Greeter", DocsetType, "github.com/gowalker/hello/index.html#//apple_ref/cpp/Type/Greeter"},
+		{"Greeter.Greet", DocsetMethod, "github.com/gowalker/hello/index.html#//apple_ref/cpp/Method/Greeter.Greet"},
+	}
+	if len(e.entries) != len(wantEntries) {
+		t.Fatalf("entries = %v", e.entries)
+	}
+	for i := range wantEntries {
+		if *e.entries[i] != wantEntries[i] {
+			t.Errorf("entries[%d] = %+v, want %+v", i, *e.entries[i], wantEntries[i])
+		}
+	}
+}
+
+func TestRemoveStaleArchives(t *testing.T) {
+	dir, err := ioutil.TempDir("", "docset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	prefix := dir + "/a/b.docset."
+	os.MkdirAll(dir+"/a/b", os.ModePerm)
+	for _, name := range []string{"a/b.docset.1.tgz", "a/b.docset.2.tgz", "a/b/c.docset.1.tgz", "a/bc.docset.1.tgz"} {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	removeStaleArchives(prefix, prefix+"2.tgz")
+	for name, want := range map[string]bool{
+		"a/b.docset.1.tgz":   false,
+		"a/b.docset.2.tgz":   true,
+		"a/b/c.docset.1.tgz": true,
+		"a/bc.docset.1.tgz":  true,
+	} {
+		if got := com.IsFile(filepath.Join(dir, name)); got != want {
+			t.Errorf("%s exists = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/unknwon/com"
+	"gopkg.in/macaron.v1"
+
+	"github.com/unknwon/gowalker/internal/db"
+	"github.com/unknwon/gowalker/internal/setting"
+)
+
+const (
+	EXPORT_DOCS    = "export/docs"
+	EXPORT_IMPORTS = "export/imports"
+	EXPORT_INDEX   = "export/index"
+)
+
+// ExportSiteURL is used for links to packages that are not part of the export.
+const ExportSiteURL = "https://gowalker.org/"
+
+// exportedPackage is a package listed in pages of an exported site.
+type exportedPackage struct {
+	ImportPath string
+	Name       string
+	Synopsis   string
+	Link       string // Empty if the package is not exported.
+
+	IsNestedModule bool
+}
+
+// searchEntry is a record of the client-side search index.
+type searchEntry struct {
+	Title    string `json:"title"`
+	Synopsis string `json:"synopsis,omitempty"`
+	URL      string `json:"url"`
+}
+
+// siteExporter writes documentation of a set of packages as a static site.
+type siteExporter struct {
+	render   macaron.Render
+	dest     string
+	exported map[string]bool
+	index    []searchEntry
+
+	// docset indicates whether pages are generated for a Dash docset.
+	docset  bool
+	entries []*docsetEntry
+}
+
+// relRoot returns relative path from the directory of package page to the site root.
+func relRoot(importPath string) string {
+	return strings.Repeat("../", strings.Count(importPath, "/")+1)
+}
+
+// link returns relative link from the page at root to the documentation of
+// given package, it returns an empty string if the package is not exported.
+func (e *siteExporter) link(root, importPath string) string {
+	if !e.exported[importPath] {
+		return ""
+	}
+	return root + importPath + "/index.html"
+}
+
+var absLinkPattern = regexp.MustCompile(`href="/([^"#?]*)(#[^"]*)?"`)
+
+// rewriteLinks makes links to exported packages relative to the page at root,
+// and links to other packages absolute to the public site.
+func (e *siteExporter) rewriteLinks(root string, html []byte) []byte {
+	return absLinkPattern.ReplaceAllFunc(html, func(m []byte) []byte {
+		sub := absLinkPattern.FindSubmatch(m)
+		importPath, anchor := strings.TrimSuffix(string(sub[1]), "/"), string(sub[2])
+		if link := e.link(root, importPath); len(link) > 0 {
+			return []byte(`href="` + link + anchor + `"`)
+		}
+		return []byte(`href="` + ExportSiteURL + importPath + anchor + `"`)
+	})
+}
+
+func (e *siteExporter) packages(root string, pinfos []*db.PkgInfo) []*exportedPackage {
+	pkgs := make([]*exportedPackage, len(pinfos))
+	for i, pinfo := range pinfos {
+		pkgs[i] = &exportedPackage{
+			ImportPath: pinfo.ImportPath,
+			Name:       pinfo.Name,
+			Synopsis:   pinfo.Synopsis,
+			Link:       e.link(root, pinfo.ImportPath),
+
+			IsNestedModule: pinfo.IsNestedModule,
+		}
+	}
+	return pkgs
+}
+
+func (e *siteExporter) writePage(name, tpl string, data map[string]interface{}) error {
+	data["AppVer"] = setting.AppVer
+	result, err := e.render.HTMLBytes(tpl, data)
+	if err != nil {
+		return fmt.Errorf("render '%s': %v", tpl, err)
+	}
+
+	name = filepath.Join(e.dest, filepath.FromSlash(name))
+	os.MkdirAll(filepath.Dir(name), os.ModePerm)
+	return ioutil.WriteFile(name, result, 0644)
+}
+
+// exportPackage writes documentation, imports and references pages of the package.
+func (e *siteExporter) exportPackage(importPath string) error {
+	pinfo, err := db.GetPkgInfo(importPath)
+	if err != nil && (err != db.ErrPackageVersionTooOld || pinfo == nil) {
+		return fmt.Errorf("get package info: %v", err)
+	}
+
+	pdoc, err := loadGob(importPath)
+	if err != nil {
+		return err
+	}
+
+	result, err := renderDocHTML(e.render, pdoc)
+	if err != nil {
+		return fmt.Errorf("render doc: %v", err)
+	}
+
+	root := relRoot(importPath)
+	result = e.rewriteLinks(root, result)
+	if e.docset {
+		result = e.addDashAnchors(importPath, pdoc, result)
+	}
+
+	data := map[string]interface{}{
+		"Root":        root,
+		"Title":       importPath,
+		"ImportPath":  importPath,
+		"ParentPath":  path.Dir(importPath),
+		"ParentLink":  e.link(root, path.Dir(importPath)),
+		"ProjectName": path.Base(importPath),
+		"ProjectPath": pdoc.ProjectPath,
+		"PkgDesc":     pdoc.Synopsis,
+		"ImportNum":   pinfo.ImportNum,
+		"RefNum":      pinfo.RefNum,
+		"Doc":         string(result),
+	}
+	if readme := pdoc.Readme["en"]; len(readme) > 0 {
+		data["Readme"] = string(e.rewriteLinks(root, readme))
+	}
+	if len(pinfo.Subdirs) > 0 {
+		data["Subdirs"] = e.packages(root, pinfo.GetSubPkgs())
+	}
+	if err = e.writePage(importPath+"/index.html", EXPORT_DOCS, data); err != nil {
+		return err
+	}
+
+	data["PageIsImports"] = true
+	data["Packages"] = e.packages(root, db.GetPkgInfosByPaths(strings.Split(pinfo.ImportPaths, "|")))
+	if err = e.writePage(importPath+"/imports.html", EXPORT_IMPORTS, data); err != nil {
+		return err
+	}
+
+	delete(data, "PageIsImports")
+	data["Packages"] = e.packages(root, pinfo.GetRefs())
+	if err = e.writePage(importPath+"/refs.html", EXPORT_IMPORTS, data); err != nil {
+		return err
+	}
+
+	// Add package and its exported objects to search index.
+	url := importPath + "/index.html"
+	e.index = append(e.index, searchEntry{importPath, pdoc.Synopsis, url})
+	name := path.Base(importPath) + "."
+	for _, f := range pdoc.Funcs {
+		e.index = append(e.index, searchEntry{Title: name + f.Name, URL: url + "#" + f.Name})
+	}
+	for _, t := range pdoc.Types {
+		e.index = append(e.index, searchEntry{Title: name + t.Name, URL: url + "#" + t.Name})
+		for _, f := range t.Funcs {
+			e.index = append(e.index, searchEntry{Title: name + f.Name, URL: url + "#" + f.Name})
+		}
+		for _, m := range t.Methods {
+			e.index = append(e.index, searchEntry{
+				Title: name + t.Name + "." + m.Name,
+				URL:   url + "#" + t.Name + "_" + m.Name,
+			})
+		}
+	}
+	return nil
+}
+
+func newSiteExporter(render macaron.Render, importPaths []string, dest string) *siteExporter {
+	e := &siteExporter{
+		render:   render,
+		dest:     dest,
+		exported: make(map[string]bool, len(importPaths)),
+	}
+	sort.Strings(importPaths)
+	for _, importPath := range importPaths {
+		e.exported[importPath] = true
+	}
+	return e
+}
+
+// export writes pages of given packages, assets in publicDir and search index.
+func (e *siteExporter) export(importPaths []string, publicDir string) error {
+	// LESS sources and editor configuration are not needed by pages.
+	if err := com.CopyDir(publicDir, e.dest, func(filePath string) bool {
+		return strings.HasPrefix(filePath, "less") || strings.HasSuffix(filePath, ".codekit")
+	}); err != nil {
+		return fmt.Errorf("copy assets: %v", err)
+	}
+
+	for _, importPath := range importPaths {
+		if err := e.exportPackage(importPath); err != nil {
+			return fmt.Errorf("export '%s': %v", importPath, err)
+		}
+	}
+
+	indexSrc, err := json.Marshal(e.index)
+	if err != nil {
+		return fmt.Errorf("encode search index: %v", err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(e.dest, "js", "search-index.js"),
+		[]byte("var searchIndex = "+string(indexSrc)+";\n"), 0644); err != nil {
+		return fmt.Errorf("save search index: %v", err)
+	}
+
+	pkgs := make([]*exportedPackage, 0, len(importPaths))
+	for _, entry := range e.index {
+		if e.exported[entry.Title] {
+			pkgs = append(pkgs, &exportedPackage{
+				ImportPath: entry.Title,
+				Synopsis:   entry.Synopsis,
+				Link:       entry.URL,
+			})
+		}
+	}
+	return e.writePage("index.html", EXPORT_INDEX, map[string]interface{}{
+		"Title":      "Packages",
+		"PageIsHome": true,
+		"Packages":   pkgs,
+	})
+}
+
+// ExportSite writes documentation of given packages to dest as a self-contained
+// static site, including assets in publicDir and a client-side search index.
+// Packages must have their gob files stored, and dest must not exist.
+func ExportSite(render macaron.Render, importPaths []string, publicDir, dest string) error {
+	return newSiteExporter(render, importPaths, dest).export(importPaths, publicDir)
+}
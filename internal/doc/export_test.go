@@ -0,0 +1,48 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"testing"
+)
+
+func TestSiteExporter_RewriteLinks(t *testing.T) {
+	e := &siteExporter{
+		exported: map[string]bool{
+			"github.com/gowalker/hello":     true,
+			"github.com/gowalker/hello/sub": true,
+		},
+	}
+
+	root := relRoot("github.com/gowalker/hello/sub")
+	if root != "../../../../" {
+		t.Fatalf("relRoot = %q", root)
+	}
+
+	tests := []struct {
+		html, want string
+	}{
+		{`<a href="/github.com/gowalker/hello#Greet">`, `<a href="../../../../github.com/gowalker/hello/index.html#Greet">`},
+		{`<a href="/github.com/gowalker/hello/sub/">`, `<a href="../../../../github.com/gowalker/hello/sub/index.html">`},
+		{`<a href="/fmt#Println">`, `<a href="https://gowalker.org/fmt#Println">`},
+		{`<a href="#Greeter">`, `<a href="#Greeter">`},
+		{`<a href="https://github.com">`, `<a href="https://github.com">`},
+	}
+	for _, test := range tests {
+		if got := string(e.rewriteLinks(root, []byte(test.html))); got != test.want {
+			t.Errorf("rewriteLinks(%q) = %q, want %q", test.html, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"go/ast"
+	"strconv"
+	"strings"
+)
+
+// FieldAnchor returns anchor name of the field or the interface method of type.
+func FieldAnchor(typeName, name string) string {
+	return typeName + "." + name
+}
+
+// structTag is a key-value pair of struct tag.
+type structTag struct {
+	key, value string
+}
+
+// parseStructTag parses struct tag in the conventional format, pairs are
+// returned in the order of appearance. It stops at the first malformed pair.
+func parseStructTag(tag string) []structTag {
+	var tags []structTag
+	for tag != "" {
+		// Skip leading space.
+		tag = strings.TrimLeft(tag, " ")
+		if tag == "" {
+			break
+		}
+
+		// Scan to colon, a space, a quote or a control character is a syntax error.
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+
+		// Scan quoted string to find value.
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		value, err := strconv.Unquote(tag[:i+1])
+		if err != nil {
+			break
+		}
+		tags = append(tags, structTag{key, value})
+		tag = tag[i+1:]
+	}
+	return tags
+}
+
+// embeddedName returns name of embedded field by its type.
+func embeddedName(x ast.Expr) string {
+	switch t := x.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.IndexExpr:
+		return embeddedName(t.X)
+	case *ast.IndexListExpr:
+		return embeddedName(t.X)
+	}
+	return ""
+}
+
+// fieldDoc returns doc comment of field, the line comment is used if there is
+// no doc comment.
+func fieldDoc(f *ast.Field) string {
+	if f.Doc != nil {
+		return f.Doc.Text()
+	}
+	return f.Comment.Text()
+}
+
+// fields returns exported fields of struct or methods of interface declared
+// by the type, and keys of struct tags of fields.
+func (w *Walker) fields(decl *ast.GenDecl) (fields, methods []*Field, tagKeys []string) {
+	spec, ok := decl.Specs[0].(*ast.TypeSpec)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	switch t := spec.Type.(type) {
+	case *ast.StructType:
+		tagValues := make([]map[string]string, 0, len(t.Fields.List))
+		seenKeys := make(map[string]bool)
+		for _, f := range t.Fields.List {
+			names := make([]string, 0, len(f.Names))
+			for _, name := range f.Names {
+				names = append(names, name.Name)
+			}
+			if len(f.Names) == 0 {
+				names = append(names, embeddedName(f.Type))
+			}
+
+			var parsed []structTag
+			if f.Tag != nil {
+				tag, _ := strconv.Unquote(f.Tag.Value)
+				parsed = parseStructTag(tag)
+			}
+			tags := make(map[string]string, len(parsed))
+			for _, t := range parsed {
+				tags[t.key] = t.value
+			}
+
+			doc := fieldDoc(f)
+			typ := w.printNode(f.Type)
+			numFields := len(fields)
+			for _, name := range names {
+				if !ast.IsExported(name) {
+					continue
+				}
+				fields = append(fields, &Field{
+					Name:       name,
+					Type:       typ,
+					Doc:        doc,
+					Embedded:   len(f.Names) == 0,
+					Deprecated: deprecationNote(doc),
+				})
+				tagValues = append(tagValues, tags)
+			}
+
+			// Keys only used by unexported fields are left out of the table.
+			if len(fields) == numFields {
+				continue
+			}
+			for _, t := range parsed {
+				if !seenKeys[t.key] {
+					seenKeys[t.key] = true
+					tagKeys = append(tagKeys, t.key)
+				}
+			}
+		}
+
+		for i, f := range fields {
+			for _, key := range tagKeys {
+				f.TagValues = append(f.TagValues, tagValues[i][key])
+			}
+		}
+
+	case *ast.InterfaceType:
+		for _, f := range t.Methods.List {
+			doc := fieldDoc(f)
+			if len(f.Names) == 0 {
+				if name := embeddedName(f.Type); ast.IsExported(name) {
+					methods = append(methods, &Field{
+						Name:       name,
+						Type:       w.printNode(f.Type),
+						Doc:        doc,
+						Embedded:   true,
+						Deprecated: deprecationNote(doc),
+					})
+				}
+				continue
+			}
+
+			sig := strings.TrimPrefix(w.printNode(f.Type), "func")
+			for _, name := range f.Names {
+				if !ast.IsExported(name.Name) {
+					continue
+				}
+				methods = append(methods, &Field{
+					Name:       name.Name,
+					Type:       sig,
+					Doc:        doc,
+					Deprecated: deprecationNote(doc),
+				})
+			}
+		}
+	}
+	return fields, methods, tagKeys
+}
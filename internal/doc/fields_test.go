@@ -0,0 +1,115 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+)
+
+func TestParseStructTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want []structTag
+	}{
+		{``, nil},
+		{`json:"name,omitempty" xorm:"UNIQUE"`, []structTag{{"json", "name,omitempty"}, {"xorm", "UNIQUE"}}},
+		{`  ini:"a \"b\""`, []structTag{{"ini", `a "b"`}}},
+		{`json:"a" bad`, []structTag{{"json", "a"}}},
+		{`bad:a`, nil},
+	}
+	for _, test := range tests {
+		if got := parseStructTag(test.tag); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("parseStructTag(%q): got %v, want %v", test.tag, got, test.want)
+		}
+	}
+}
+
+func TestWalker_Fields(t *testing.T) {
+	pdoc := buildTestPackage(t, "github.com/gowalker/conf", &Source{SrcName: "conf.go", SrcData: []byte(`package conf
+
+import "io"
+
+type Base struct{}
+
+type Config struct {
+	*Base
+
+	// Name is the name of app.
+	Name     string ` + "`json:\"name\"`" + `
+	Addr     string // Listen address.
+	Port     int    ` + "`ini:\"PORT\" json:\"port\"`" + `
+	internal bool
+}
+
+type Source interface {
+	io.Reader
+
+	// Load loads the config.
+	Load(path string) (*Config, error)
+	reset()
+}
+`)})
+
+	tps := make(map[string]*Type)
+	for _, tp := range pdoc.Types {
+		tps[tp.Name] = tp
+	}
+
+	cfg := tps["Config"]
+	if want := []string{"json", "ini"}; !reflect.DeepEqual(cfg.TagKeys, want) {
+		t.Errorf("Tag keys: got %v, want %v", cfg.TagKeys, want)
+	}
+	wantFields := []*Field{
+		{Name: "Base", Type: "*Base", Embedded: true, TagValues: []string{"", ""}},
+		{Name: "Name", Type: "string", Doc: "Name is the name of app.\n", TagValues: []string{"name", ""}},
+		{Name: "Addr", Type: "string", Doc: "Listen address.\n", TagValues: []string{"", ""}},
+		{Name: "Port", Type: "int", TagValues: []string{"port", "PORT"}},
+	}
+	if !reflect.DeepEqual(cfg.Fields, wantFields) {
+		for _, f := range cfg.Fields {
+			t.Logf("%+v", f)
+		}
+		t.Errorf("Fields mismatch")
+	}
+
+	wantMethods := []*Field{
+		{Name: "Reader", Type: "io.Reader", Embedded: true},
+		{Name: "Load", Type: "(path string) (*Config, error)", Doc: "Load loads the config.\n"},
+	}
+	if !reflect.DeepEqual(tps["Source"].InterfaceMethods, wantMethods) {
+		for _, f := range tps["Source"].InterfaceMethods {
+			t.Logf("%+v", f)
+		}
+		t.Errorf("Interface methods mismatch")
+	}
+}
+
+func TestWalker_FieldsTagKeys(t *testing.T) {
+	// Unexported fields are kept in AST when all declarations are walked.
+	src := "package conf\n\ntype Config struct {\n\tName string `json:\"name\"`\n\tcache bool `xorm:\"-\"`\n}\n"
+	w := &Walker{Fset: token.NewFileSet()}
+	file, err := parser.ParseFile(w.Fset, "conf.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields, _, tagKeys := w.fields(file.Decls[0].(*ast.GenDecl))
+	if len(fields) != 1 || !reflect.DeepEqual(tagKeys, []string{"json"}) {
+		t.Errorf("got %d fields with tag keys %v, want 1 field with [json]", len(fields), tagKeys)
+	}
+}
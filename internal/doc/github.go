@@ -90,9 +90,46 @@ func getGitHubDoc(match map[string]string, etag string) (*Package, error) {
 		match["tag"] = repoInfo.DefaultBranch
 	}
 
+	// Resolve major version suffix of import path to a subdirectory, or the latest
+	// tag of the major version of module in its parent directory.
+	var lookupErr error
+	var modDir, major, majorTag string
+	match["dir"], modDir, major = resolveMajorDir(match["dir"], func(dir string) bool {
+		var file struct {
+			Path string
+		}
+		url := com.Expand("https://api.github.com/repos/{owner}/{repo}/contents/{0}/go.mod?ref={tag}", match, dir)
+		if err := httpGet(url, &file); err != nil {
+			lookupErr = err
+		}
+		return len(file.Path) > 0
+	})
+	if lookupErr != nil {
+		return nil, fmt.Errorf("get go.mod of major version: %v", lookupErr)
+	} else if len(major) > 0 {
+		prefix := major + "."
+		if len(modDir) > 0 {
+			prefix = modDir + "/" + prefix
+		}
+		var refs []struct {
+			Ref string
+		}
+		if err := httpGet(com.Expand("https://api.github.com/repos/{owner}/{repo}/git/matching-refs/tags/{0}", match, prefix), &refs); err != nil {
+			return nil, fmt.Errorf("get tags of major version: %v", err)
+		}
+		tags := make([]string, len(refs))
+		for i := range refs {
+			tags[i] = strings.TrimPrefix(refs[i].Ref, "refs/tags/")
+		}
+		// Otherwise the default branch is used like pseudo-versions.
+		if majorTag = latestMajorTag(tags, modDir, major); len(majorTag) > 0 {
+			match["tag"] = majorTag
+		}
+	}
+
 	// Check if last commit time is behind upstream for fork repository.
 	if repoInfo.Fork {
-		url := com.Expand("https://api.github.com/repos/{owner}/{repo}/commits?per_page=1&{cred}", match)
+		url := com.Expand("https://api.github.com/repos/{owner}/{repo}/commits?per_page=1", match)
 		forkCommits := make([]*RepoCommit, 0, 1)
 		if err := httpGet(url, &forkCommits); err != nil {
 			return nil, fmt.Errorf("get fork repository commits: %v", err)
@@ -102,7 +139,7 @@ func getGitHubDoc(match map[string]string, etag string) (*Package, error) {
 		}
 
 		match["parent"] = repoInfo.Parent.FullName
-		url = com.Expand("https://api.github.com/repos/{parent}/commits?per_page=1&{cred}", match)
+		url = com.Expand("https://api.github.com/repos/{parent}/commits?per_page=1", match)
 		parentCommits := make([]*RepoCommit, 0, 1)
 		if err := httpGet(url, &parentCommits); err != nil {
 			return nil, fmt.Errorf("get parent repository commits: %v", err)
@@ -170,9 +207,24 @@ func getGitHubDoc(match map[string]string, etag string) (*Package, error) {
 	dirLevel := len(strings.Split(dirPrefix, "/"))
 	dirLength := len(dirPrefix)
 	dirMap := make(map[string]bool)
+	nestedMap := make(map[string]bool)
 	files := make([]com.RawFile, 0, 10)
+	parents := newNearestFiles(dirPrefix)
 
 	for _, node := range tree.Tree {
+		// License files and go.mod may be in parent directories.
+		if d, f := path.Split(node.Path); node.Type == "blob" && isParentFile(f) {
+			if dir, ok := nestedModuleDir(dirPrefix, node.Path); ok {
+				nestedMap[dir] = true
+			}
+			parents.add(d, &Source{
+				SrcName:   f,
+				BrowseUrl: com.Expand("github.com/{owner}/{repo}/blob/{tag}/{0}", match, node.Path),
+				RawSrcUrl: com.Expand("https://raw.github.com/{owner}/{repo}/{tag}/{0}", match, node.Path),
+			})
+			continue
+		}
+
 		// Skip directories and files in wrong directories, get them later.
 		if node.Type != "blob" || !strings.HasPrefix(node.Path, dirPrefix) {
 			continue
@@ -202,7 +254,9 @@ func getGitHubDoc(match map[string]string, etag string) (*Package, error) {
 
 	if len(files) == 0 && len(dirs) == 0 {
 		return nil, ErrPackageNoGoFile
-	} else if err := com.FetchFiles(Client, files, githubRawHeader); err != nil {
+	}
+	files = append(files, parents.all()...)
+	if err := com.FetchFiles(Client, files, githubRawHeader); err != nil {
 		return nil, fmt.Errorf("fetch files: %v", err)
 	}
 
@@ -217,6 +271,8 @@ func getGitHubDoc(match map[string]string, etag string) (*Package, error) {
 				ViewDirPath: com.Expand("github.com/{owner}/{repo}/tree/{tag}/{importPath}", match),
 				Etag:        commit,
 				Subdirs:     strings.Join(dirs, "|"),
+
+				NestedModules: strings.Join(base.MapToSortedStrings(nestedMap), "|"),
 			},
 		},
 	}
@@ -242,6 +298,15 @@ func getGitHubDoc(match map[string]string, etag string) (*Package, error) {
 		return nil, fmt.Errorf("error walking package: %v", err)
 	}
 
+	// The module in parent directory must be declared as the major version.
+	if len(major) > 0 {
+		modPath := path.Join(com.Expand("github.com/{owner}/{repo}", match), modDir, major)
+		if pdoc.Module == nil || pdoc.Module.Path != modPath {
+			return nil, com.NotFoundError{Message: fmt.Sprintf("module %q is not found in repository", modPath)}
+		}
+		pdoc.Tag = strings.TrimPrefix(majorTag, modDir+"/")
+	}
+
 	// Get stars.
 	var repoTree struct {
 		Stars int64 `json:"watchers"`
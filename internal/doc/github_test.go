@@ -0,0 +1,182 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/unknwon/gowalker/internal/license"
+)
+
+func init() {
+	license.CorpusPath = "../../conf/license"
+}
+
+func TestGetGitHubDoc(t *testing.T) {
+	defer useFixture(t, "github")()
+
+	pdoc, err := getStatic("github.com/gowalker/hello", "")
+	if err != nil {
+		t.Fatalf("getStatic: %v", err)
+	}
+
+	if pdoc.ImportPath != "github.com/gowalker/hello" {
+		t.Errorf("ImportPath = %q", pdoc.ImportPath)
+	}
+	if pdoc.ProjectPath != "github.com/gowalker/hello" {
+		t.Errorf("ProjectPath = %q", pdoc.ProjectPath)
+	}
+	if pdoc.Etag != "3c9d1e8a5b7f2c4d6e8f0a1b3c5d7e9f1a2b4c6d" {
+		t.Errorf("Etag = %q", pdoc.Etag)
+	}
+	if pdoc.Synopsis != "Package hello greets people." {
+		t.Errorf("Synopsis = %q", pdoc.Synopsis)
+	}
+	if pdoc.Subdirs != "sub" {
+		t.Errorf("Subdirs = %q", pdoc.Subdirs)
+	}
+	if pdoc.Stars != 42 {
+		t.Errorf("Stars = %d", pdoc.Stars)
+	}
+	if pdoc.ImportPaths != "fmt|io" {
+		t.Errorf("ImportPaths = %q", pdoc.ImportPaths)
+	}
+	if len(pdoc.Readme["en"]) == 0 {
+		t.Error("README is not collected")
+	}
+	if pdoc.ModulePath != "github.com/gowalker/hello" || pdoc.GoVersion != "1.21" {
+		t.Errorf("ModulePath = %q, GoVersion = %q", pdoc.ModulePath, pdoc.GoVersion)
+	}
+	if pdoc.License != "MIT" || pdoc.LicenseConfidence < license.Threshold {
+		t.Errorf("License = %q, %v", pdoc.License, pdoc.LicenseConfidence)
+	}
+
+	if len(pdoc.Files) != 1 || pdoc.Files[0].SrcName != "hello.go" {
+		t.Fatalf("Files = %v", pdoc.Files)
+	} else if pdoc.Files[0].BrowseUrl != "github.com/gowalker/hello/blob/master/hello.go" {
+		t.Errorf("BrowseUrl = %q", pdoc.Files[0].BrowseUrl)
+	}
+	if len(pdoc.TestFiles) != 1 || pdoc.TestFiles[0].SrcName != "hello_test.go" {
+		t.Errorf("TestFiles = %v", pdoc.TestFiles)
+	}
+
+	if len(pdoc.Consts) != 1 || !strings.Contains(pdoc.Consts[0].Decl, "DefaultGreeting") {
+		t.Errorf("Consts = %v", pdoc.Consts)
+	}
+	if len(pdoc.Funcs) != 1 || pdoc.Funcs[0].Name != "Greet" {
+		t.Errorf("Funcs = %v", pdoc.Funcs)
+	}
+	// Unexported declarations are dropped without WalkRes.BuildAll.
+	if len(pdoc.Ifuncs) != 0 {
+		t.Errorf("Ifuncs = %v", pdoc.Ifuncs)
+	}
+	if len(pdoc.Types) != 1 {
+		t.Fatalf("Types = %v", pdoc.Types)
+	}
+	tp := pdoc.Types[0]
+	if tp.Name != "Greeter" {
+		t.Errorf("Types[0].Name = %q", tp.Name)
+	}
+	if len(tp.Funcs) != 1 || tp.Funcs[0].Name != "NewGreeter" {
+		t.Errorf("Greeter.Funcs = %v", tp.Funcs)
+	}
+	if len(tp.Methods) != 1 || tp.Methods[0].Name != "Greet" {
+		t.Errorf("Greeter.Methods = %v", tp.Methods)
+	} else if tp.Methods[0].URL != "github.com/gowalker/hello/blob/master/hello.go#L25" {
+		t.Errorf("Greeter.Greet URL = %q", tp.Methods[0].URL)
+	}
+
+	if len(pdoc.Examples) != 1 {
+		t.Fatalf("Examples = %v", pdoc.Examples)
+	} else if ex := pdoc.Examples[0]; ex.Name != "Greet" || ex.Output != "Hello, Go Walker!\n" {
+		t.Errorf("Examples[0] = %+v", ex)
+	}
+}
+
+func TestGetGitHubDoc_NotModified(t *testing.T) {
+	defer useFixture(t, "github")()
+
+	_, err := getStatic("github.com/gowalker/hello", "3c9d1e8a5b7f2c4d6e8f0a1b3c5d7e9f1a2b4c6d")
+	if err != ErrPackageNotModified {
+		t.Fatalf("err = %v, want %v", err, ErrPackageNotModified)
+	}
+}
+
+func TestGetGitHubDoc_ForkBehindParent(t *testing.T) {
+	defer useFixture(t, "github_fork")()
+
+	_, err := getStatic("github.com/gowalker/hello-fork", "")
+	if err == nil {
+		t.Fatal("Fork behind its parent is accepted")
+	} else if !strings.Contains(err.Error(), "behind or equal to its parent: gowalker/hello") {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestGetGitHubDoc_MajorVersion(t *testing.T) {
+	defer useFixture(t, "github_major")()
+
+	// Major branch layout uses the latest release tag of the major version.
+	pdoc, err := getStatic("github.com/gowalker/multi/v2", "")
+	if err != nil {
+		t.Fatalf("getStatic: %v", err)
+	}
+	if pdoc.ImportPath != "github.com/gowalker/multi/v2" || pdoc.ModulePath != "github.com/gowalker/multi/v2" {
+		t.Errorf("ImportPath = %q, ModulePath = %q", pdoc.ImportPath, pdoc.ModulePath)
+	}
+	if pdoc.Tag != "v2.1.0" {
+		t.Errorf("Tag = %q", pdoc.Tag)
+	}
+	if len(pdoc.Files) != 1 || pdoc.Files[0].BrowseUrl != "github.com/gowalker/multi/blob/v2.1.0/multi.go" {
+		t.Errorf("Files = %v", pdoc.Files)
+	}
+
+	// Major subdirectory layout.
+	pdoc, err = getStatic("github.com/gowalker/multi/v3", "")
+	if err != nil {
+		t.Fatalf("getStatic: %v", err)
+	}
+	if pdoc.ModulePath != "github.com/gowalker/multi/v3" || pdoc.GoVersion != "1.21" {
+		t.Errorf("ModulePath = %q, GoVersion = %q", pdoc.ModulePath, pdoc.GoVersion)
+	}
+	if len(pdoc.Files) != 1 || pdoc.Files[0].BrowseUrl != "github.com/gowalker/multi/blob/master/v3/multi.go" {
+		t.Errorf("Files = %v", pdoc.Files)
+	}
+
+	// The module in repository root is not the major version.
+	if _, err = getStatic("github.com/gowalker/multi/v4", ""); err == nil {
+		t.Error("Nonexistent major version is found")
+	} else if !strings.Contains(err.Error(), `module "github.com/gowalker/multi/v4" is not found`) {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	pdoc, err = getStatic("github.com/gowalker/multi", "")
+	if err != nil {
+		t.Fatalf("getStatic: %v", err)
+	}
+	if pdoc.Subdirs != "sub|util|v3" || pdoc.NestedModules != "sub|v3" {
+		t.Errorf("Subdirs = %q, NestedModules = %q", pdoc.Subdirs, pdoc.NestedModules)
+	}
+}
+
+func TestGetStatic_InvalidPath(t *testing.T) {
+	if _, err := getStatic("github.com/gowalker", ""); err != ErrInvalidRemotePath {
+		t.Errorf("err = %v, want %v", err, ErrInvalidRemotePath)
+	}
+	if _, err := getStatic("gowalker.org/hello", ""); err != ErrNoServiceMatch {
+		t.Errorf("err = %v, want %v", err, ErrNoServiceMatch)
+	}
+}
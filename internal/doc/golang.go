@@ -66,9 +66,24 @@ func getGolangDoc(importPath, etag string) (*Package, error) {
 	dirLevel := len(strings.Split(dirPrefix, "/"))
 	dirLength := len(dirPrefix)
 	dirMap := make(map[string]bool)
+	nestedMap := make(map[string]bool)
 	files := make([]com.RawFile, 0, 10)
+	parents := newNearestFiles(dirPrefix)
 
 	for _, node := range tree.Tree {
+		// License files and go.mod may be in parent directories.
+		if d, f := path.Split(node.Path); node.Type == "blob" && isParentFile(f) {
+			if dir, ok := nestedModuleDir(dirPrefix, node.Path); ok {
+				nestedMap[dir] = true
+			}
+			parents.add(d, &Source{
+				SrcName:   f,
+				BrowseUrl: com.Expand("github.com/golang/go/blob/master/{0}", nil, node.Path),
+				RawSrcUrl: com.Expand("https://raw.github.com/golang/go/master/{0}", nil, node.Path),
+			})
+			continue
+		}
+
 		// Skip directories and files in irrelevant directories.
 		if node.Type != "blob" || !strings.HasPrefix(node.Path, dirPrefix) {
 			continue
@@ -98,7 +113,9 @@ func getGolangDoc(importPath, etag string) (*Package, error) {
 
 	if len(files) == 0 && len(dirs) == 0 {
 		return nil, ErrPackageNoGoFile
-	} else if err := com.FetchFiles(Client, files, githubRawHeader); err != nil {
+	}
+	files = append(files, parents.all()...)
+	if err := com.FetchFiles(Client, files, githubRawHeader); err != nil {
 		return nil, fmt.Errorf("fetch files: %v", err)
 	}
 
@@ -113,6 +130,8 @@ func getGolangDoc(importPath, etag string) (*Package, error) {
 				Etag:        commit,
 				IsGoRepo:    true,
 				Subdirs:     strings.Join(dirs, "|"),
+
+				NestedModules: strings.Join(base.MapToSortedStrings(nestedMap), "|"),
 			},
 		},
 	}
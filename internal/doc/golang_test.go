@@ -0,0 +1,69 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"testing"
+)
+
+func TestGetGolangDoc(t *testing.T) {
+	defer useFixture(t, "golang")()
+
+	pdoc, err := getGolangDoc("errors", "")
+	if err != nil {
+		t.Fatalf("getGolangDoc: %v", err)
+	}
+
+	if !pdoc.IsGoRepo {
+		t.Error("IsGoRepo = false")
+	}
+	if pdoc.ProjectPath != "github.com/golang/go" {
+		t.Errorf("ProjectPath = %q", pdoc.ProjectPath)
+	}
+	if pdoc.ViewDirPath != "github.com/golang/go/tree/master/src/errors" {
+		t.Errorf("ViewDirPath = %q", pdoc.ViewDirPath)
+	}
+	if pdoc.Etag != "9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d" {
+		t.Errorf("Etag = %q", pdoc.Etag)
+	}
+	if pdoc.Synopsis != "Package errors implements functions to manipulate errors." {
+		t.Errorf("Synopsis = %q", pdoc.Synopsis)
+	}
+	// Nested directories and packages sharing the prefix must be left out.
+	if pdoc.Subdirs != "unwrap" {
+		t.Errorf("Subdirs = %q", pdoc.Subdirs)
+	}
+
+	if len(pdoc.Funcs) != 1 || pdoc.Funcs[0].Name != "New" {
+		t.Fatalf("Funcs = %v", pdoc.Funcs)
+	} else if pdoc.Funcs[0].URL != "github.com/golang/go/blob/master/src/errors/errors.go#L9" {
+		t.Errorf("New URL = %q", pdoc.Funcs[0].URL)
+	}
+	if len(pdoc.Types) != 0 || len(pdoc.Itypes) != 0 {
+		t.Errorf("Types = %v, Itypes = %v", pdoc.Types, pdoc.Itypes)
+	}
+	if len(pdoc.Examples) != 1 || pdoc.Examples[0].Name != "New" {
+		t.Errorf("Examples = %v", pdoc.Examples)
+	}
+}
+
+func TestGetGolangDoc_NotModified(t *testing.T) {
+	defer useFixture(t, "golang")()
+
+	_, err := getGolangDoc("errors", "9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d")
+	if err != ErrPackageNotModified {
+		t.Fatalf("err = %v, want %v", err, ErrPackageNotModified)
+	}
+}
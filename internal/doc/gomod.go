@@ -0,0 +1,458 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var pseudoVersionPattern = regexp.MustCompile(`[-.]\d{14}-[0-9a-f]{12}$`)
+
+// isPseudoVersion returns true if the version is a pseudo-version that refers
+// to a commit, e.g. "v0.0.0-20190804042917-757f69c95f3e".
+func isPseudoVersion(v string) bool {
+	return pseudoVersionPattern.MatchString(strings.TrimSuffix(v, "+incompatible"))
+}
+
+// isMajorSuffix returns true if the path element is a major version suffix
+// of module path, e.g. "v2".
+func isMajorSuffix(elem string) bool {
+	if len(elem) < 2 || elem[0] != 'v' || elem[1] == '0' || elem == "v1" {
+		return false
+	}
+	for _, c := range elem[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ModVersion is a module path with a version, version is empty when it refers
+// to all versions of the module or a local directory.
+type ModVersion struct {
+	Path    string
+	Version string
+}
+
+// ModRequire is a requirement of module.
+type ModRequire struct {
+	ModVersion
+	Indirect bool
+}
+
+// ModReplace is a replacement of module.
+type ModReplace struct {
+	Old, New ModVersion
+}
+
+// IsLocal returns true if the module is replaced by a local directory.
+func (r *ModReplace) IsLocal() bool {
+	return strings.HasPrefix(r.New.Path, "./") || strings.HasPrefix(r.New.Path, "../") ||
+		strings.HasPrefix(r.New.Path, "/")
+}
+
+// ModRetract is a retracted version or range of versions of module.
+type ModRetract struct {
+	Low, High string // Same for single version.
+	Rationale string
+}
+
+// Module is the module that a package belongs to, parsed from the go.mod file.
+type Module struct {
+	Path      string
+	GoVersion string // Minimum Go version by "go" directive.
+	Requires  []*ModRequire
+	Replaces  []*ModReplace
+	Retracts  []*ModRetract
+}
+
+// modLine is a line of go.mod file split into tokens.
+type modLine struct {
+	tokens  []string
+	comment string // Trailing comment without "//".
+}
+
+// splitModLine splits a line of go.mod file into tokens and the trailing comment.
+func splitModLine(line string) ([]string, string, error) {
+	var tokens []string
+	for {
+		line = strings.TrimLeft(line, " \t")
+		switch {
+		case len(line) == 0:
+			return tokens, "", nil
+		case strings.HasPrefix(line, "//"):
+			return tokens, strings.TrimSpace(line[2:]), nil
+		case strings.HasPrefix(line, "=>"):
+			tokens = append(tokens, "=>")
+			line = line[2:]
+		case strings.ContainsRune("()[],", rune(line[0])):
+			tokens = append(tokens, line[:1])
+			line = line[1:]
+		case line[0] == '"' || line[0] == '`':
+			quoted, err := strconv.QuotedPrefix(line)
+			if err != nil {
+				return nil, "", err
+			}
+			s, _ := strconv.Unquote(quoted)
+			tokens = append(tokens, s)
+			line = line[len(quoted):]
+		default:
+			i := strings.IndexAny(line, " \t()[],\"`")
+			if j := strings.Index(line, "//"); j > -1 && (i == -1 || j < i) {
+				i = j
+			}
+			if i == -1 {
+				i = len(line)
+			}
+			tokens = append(tokens, line[:i])
+			line = line[i:]
+		}
+	}
+}
+
+// parseGoMod parses the module path, "go" directive and requirements in go.mod
+// file, other directives are ignored.
+func parseGoMod(data []byte) (*Module, error) {
+	m := new(Module)
+
+	block := ""           // Verb of current block, e.g. "require".
+	var comments []string // Comment lines before current line.
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for num := 1; s.Scan(); num++ {
+		tokens, comment, err := splitModLine(s.Text())
+		if err != nil {
+			return nil, fmt.Errorf("go.mod:%d: %v", num, err)
+		}
+		if len(tokens) == 0 {
+			if len(comment) > 0 {
+				comments = append(comments, comment)
+			} else {
+				comments = nil
+			}
+			continue
+		}
+
+		line := &modLine{comment: comment}
+		switch {
+		case len(block) > 0 && len(tokens) == 1 && tokens[0] == ")":
+			block = ""
+			comments = nil
+			continue
+		case len(block) > 0:
+			line.tokens = append([]string{block}, tokens...)
+		case len(tokens) == 2 && tokens[1] == "(":
+			block = tokens[0]
+			comments = nil
+			continue
+		default:
+			line.tokens = tokens
+		}
+
+		if err = m.parseLine(line, comments); err != nil {
+			return nil, fmt.Errorf("go.mod:%d: %v", num, err)
+		}
+		comments = nil
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(m.Path) == 0 {
+		return nil, fmt.Errorf("go.mod: no module directive")
+	}
+	return m, nil
+}
+
+func (m *Module) parseLine(line *modLine, comments []string) error {
+	verb, args := line.tokens[0], line.tokens[1:]
+	switch verb {
+	case "module":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: module module/path")
+		}
+		m.Path = args[0]
+	case "go":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: go 1.23")
+		}
+		m.GoVersion = args[0]
+	case "require":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: require module/path v1.2.3")
+		}
+		m.Requires = append(m.Requires, &ModRequire{
+			ModVersion: ModVersion{Path: args[0], Version: args[1]},
+			Indirect:   line.comment == "indirect" || strings.HasPrefix(line.comment, "indirect;"),
+		})
+	case "replace":
+		i := 0
+		for i < len(args) && args[i] != "=>" {
+			i++
+		}
+		if i == len(args) || i < 1 || i > 2 || len(args)-i-1 < 1 || len(args)-i-1 > 2 {
+			return fmt.Errorf("usage: replace module/path [v1.2.3] => other/module v1.4")
+		}
+		from, to := args[:i], args[i+1:]
+		r := &ModReplace{Old: ModVersion{Path: from[0]}, New: ModVersion{Path: to[0]}}
+		if len(from) == 2 {
+			r.Old.Version = from[1]
+		}
+		if len(to) == 2 {
+			r.New.Version = to[1]
+		}
+		m.Replaces = append(m.Replaces, r)
+	case "retract":
+		r := &ModRetract{Rationale: strings.Join(comments, "\n")}
+		if len(r.Rationale) == 0 {
+			r.Rationale = line.comment
+		}
+		switch {
+		case len(args) == 1:
+			r.Low, r.High = args[0], args[0]
+		case len(args) == 5 && args[0] == "[" && args[2] == "," && args[4] == "]":
+			r.Low, r.High = args[1], args[3]
+		default:
+			return fmt.Errorf("usage: retract v1.2.3 or retract [v1.2.3, v1.3.0]")
+		}
+		m.Retracts = append(m.Retracts, r)
+	}
+	return nil
+}
+
+// Requirement returns the module that provides the package with given import
+// path and its version, replacements are applied. It returns false if the
+// module is not required or it is replaced by a local directory.
+func (m *Module) Requirement(importPath string) (ModVersion, bool) {
+	var req *ModRequire
+	for _, r := range m.Requires {
+		if (importPath == r.Path || strings.HasPrefix(importPath, r.Path+"/")) &&
+			(req == nil || len(r.Path) > len(req.Path)) {
+			req = r
+		}
+	}
+	if req == nil {
+		return ModVersion{}, false
+	}
+
+	// Replacements of specific version take precedence.
+	var rep *ModReplace
+	for _, r := range m.Replaces {
+		if r.Old.Path == req.Path && (r.Old.Version == req.Version || (len(r.Old.Version) == 0 && rep == nil)) {
+			rep = r
+		}
+	}
+	switch {
+	case rep == nil:
+		return req.ModVersion, true
+	case rep.IsLocal():
+		return ModVersion{}, false
+	}
+	return rep.New, true
+}
+
+// importVersions returns modules providing the imports and their versions,
+// in form of "<import path> <module path>@<version>" separated by "|".
+func importVersions(m *Module, imports []string) string {
+	var vers []string
+	for _, imp := range imports {
+		if v, ok := m.Requirement(imp); ok {
+			vers = append(vers, imp+" "+v.Path+"@"+v.Version)
+		}
+	}
+	return strings.Join(vers, "|")
+}
+
+// ModuleVersionURL returns URL of the source tree of the module at given version,
+// or empty string if the module is not hosted on GitHub.
+func ModuleVersionURL(modPath, version string) string {
+	parts := strings.SplitN(modPath, "/", 4)
+	if len(parts) < 3 || parts[0] != "github.com" || len(version) == 0 {
+		return ""
+	}
+
+	ref := strings.TrimSuffix(version, "+incompatible")
+	if isPseudoVersion(ref) {
+		ref = ref[strings.LastIndex(ref, "-")+1:]
+	} else if len(parts) == 4 {
+		// Tags of modules in subdirectories are prefixed by the directory,
+		// the major version suffix is not part of it.
+		dir := parts[3]
+		if i := strings.LastIndex(dir, "/"); isMajorSuffix(dir[i+1:]) {
+			if i == -1 {
+				i = 0
+			}
+			dir = dir[:i]
+		}
+		if len(dir) > 0 {
+			ref = dir + "/" + ref
+		}
+	}
+	return "https://" + strings.Join(parts[:3], "/") + "/tree/" + ref
+}
+
+// resolveMajorDir resolves the directory of a package in repository when its
+// import path has a major version suffix, e.g. "/v2/sub". Like the go command,
+// the suffix is a subdirectory if it has a go.mod file, the directory is then
+// returned as it is. Otherwise the package belongs to the major version of
+// the module rooted at the parent directory, it returns the directory without
+// the suffix, the module directory and the suffix.
+func resolveMajorDir(dir string, hasGoMod func(dir string) bool) (string, string, string) {
+	elems := strings.Split(strings.Trim(dir, "/"), "/")
+	for i, elem := range elems {
+		if !isMajorSuffix(elem) {
+			continue
+		} else if hasGoMod(strings.Join(elems[:i+1], "/")) {
+			break
+		}
+
+		modDir := strings.Join(elems[:i], "/")
+		pkgDir := strings.Join(append(elems[:i:i], elems[i+1:]...), "/")
+		if len(pkgDir) > 0 {
+			pkgDir = "/" + pkgDir
+		}
+		return pkgDir, modDir, elem
+	}
+	return dir, "", ""
+}
+
+// splitSemver splits semantic version into numbers of major, minor and patch
+// versions and the pre-release part, build metadata is ignored.
+func splitSemver(v string) ([3]int, string, bool) {
+	var nums [3]int
+	if !strings.HasPrefix(v, "v") {
+		return nums, "", false
+	}
+	v = v[1:]
+	if i := strings.Index(v, "+"); i > -1 {
+		v = v[:i]
+	}
+	pre := ""
+	if i := strings.Index(v, "-"); i > -1 {
+		v, pre = v[:i], v[i+1:]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return nums, "", false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return nums, "", false
+		}
+		nums[i] = n
+	}
+	return nums, pre, true
+}
+
+// compareSemver returns -1, 0 or 1 if version a is lower than, equal to or
+// higher than version b. Both versions must be valid.
+func compareSemver(a, b string) int {
+	an, apre, _ := splitSemver(a)
+	bn, bpre, _ := splitSemver(b)
+	for i := range an {
+		switch {
+		case an[i] < bn[i]:
+			return -1
+		case an[i] > bn[i]:
+			return 1
+		}
+	}
+
+	// Releases are higher than pre-releases.
+	switch {
+	case apre == bpre:
+		return 0
+	case len(apre) == 0:
+		return 1
+	case len(bpre) == 0:
+		return -1
+	}
+	as, bs := strings.Split(apre, "."), strings.Split(bpre, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+		x, errx := strconv.Atoi(as[i])
+		y, erry := strconv.Atoi(bs[i])
+		switch {
+		case errx == nil && erry == nil && x < y,
+			errx == nil && erry != nil,
+			errx != nil && erry != nil && as[i] < bs[i]:
+			return -1
+		}
+		return 1
+	}
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	}
+	return 0
+}
+
+// latestMajorTag returns the latest tag of the major version of module in
+// given directory, tags of modules in subdirectories are prefixed by the
+// directory, e.g. "sub/v2.1.0". It returns empty string if there is no such tag.
+func latestMajorTag(tags []string, modDir, major string) string {
+	prefix := ""
+	if len(modDir) > 0 {
+		prefix = modDir + "/"
+	}
+
+	// Like the go command, pre-releases are only used when there is no release.
+	latest, latestPre := "", ""
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, prefix+major+".") {
+			continue
+		}
+		v := tag[len(prefix):]
+		_, pre, ok := splitSemver(v)
+		if !ok {
+			continue
+		}
+		cur := &latest
+		if len(pre) > 0 {
+			cur = &latestPre
+		}
+		if len(*cur) == 0 || compareSemver(v, (*cur)[len(prefix):]) > 0 {
+			*cur = tag
+		}
+	}
+	if len(latest) == 0 {
+		return latestPre
+	}
+	return latest
+}
+
+// nestedModuleDir returns name of the subdirectory of package directory if the
+// go.mod file is right in it, which makes the subdirectory root of a nested module.
+func nestedModuleDir(pkgDir, goModPath string) (string, bool) {
+	if !strings.HasPrefix(goModPath, pkgDir) {
+		return "", false
+	}
+	dir, name := path.Split(goModPath[len(pkgDir):])
+	if name != "go.mod" || len(dir) == 0 || strings.Count(dir, "/") != 1 {
+		return "", false
+	}
+	return strings.TrimSuffix(dir, "/"), true
+}
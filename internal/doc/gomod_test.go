@@ -0,0 +1,206 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testGoMod = `// Module of tests.
+module github.com/gowalker/hello/v2
+
+go 1.21
+
+toolchain go1.22.0
+
+require github.com/unknwon/com v0.0.0-20190804042917-757f69c95f3e
+
+require (
+	github.com/gowalker/buf v1.2.0 // indirect
+	"github.com/gowalker/buf/nested" v0.3.0
+	golang.org/x/text v0.3.2
+)
+
+replace golang.org/x/text => golang.org/x/text v0.3.8
+
+replace (
+	github.com/gowalker/buf v1.2.0 => ../buf
+	github.com/unknwon/com => github.com/gowalker/com v1.0.0
+)
+
+retract (
+	// Published accidentally.
+	v2.0.0
+	[v2.1.0, v2.1.3] // Broken build.
+)
+`
+
+func TestParseGoMod(t *testing.T) {
+	m, err := parseGoMod([]byte(testGoMod))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.Path != "github.com/gowalker/hello/v2" || m.GoVersion != "1.21" {
+		t.Errorf("Path = %q, GoVersion = %q", m.Path, m.GoVersion)
+	}
+	wantRequires := []*ModRequire{
+		{ModVersion{"github.com/unknwon/com", "v0.0.0-20190804042917-757f69c95f3e"}, false},
+		{ModVersion{"github.com/gowalker/buf", "v1.2.0"}, true},
+		{ModVersion{"github.com/gowalker/buf/nested", "v0.3.0"}, false},
+		{ModVersion{"golang.org/x/text", "v0.3.2"}, false},
+	}
+	if !reflect.DeepEqual(m.Requires, wantRequires) {
+		t.Errorf("Requires = %+v", m.Requires)
+	}
+	wantReplaces := []*ModReplace{
+		{ModVersion{"golang.org/x/text", ""}, ModVersion{"golang.org/x/text", "v0.3.8"}},
+		{ModVersion{"github.com/gowalker/buf", "v1.2.0"}, ModVersion{"../buf", ""}},
+		{ModVersion{"github.com/unknwon/com", ""}, ModVersion{"github.com/gowalker/com", "v1.0.0"}},
+	}
+	if !reflect.DeepEqual(m.Replaces, wantReplaces) {
+		t.Errorf("Replaces = %+v", m.Replaces)
+	}
+	wantRetracts := []*ModRetract{
+		{"v2.0.0", "v2.0.0", "Published accidentally."},
+		{"v2.1.0", "v2.1.3", "Broken build."},
+	}
+	if !reflect.DeepEqual(m.Retracts, wantRetracts) {
+		t.Errorf("Retracts = %+v", m.Retracts)
+	}
+
+	for _, data := range []string{
+		"go 1.21\n",
+		"module a\nrequire b\n",
+		"module a\nreplace b v1.0.0\n",
+		"module a\nretract [v1.0.0\n",
+	} {
+		if _, err := parseGoMod([]byte(data)); err == nil {
+			t.Errorf("parseGoMod(%q) returned no error", data)
+		}
+	}
+}
+
+func TestModule_Requirement(t *testing.T) {
+	m, err := parseGoMod([]byte(testGoMod))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		importPath string
+		want       ModVersion
+		ok         bool
+	}{
+		{"github.com/gowalker/buf/nested/sub", ModVersion{"github.com/gowalker/buf/nested", "v0.3.0"}, true},
+		{"github.com/gowalker/buf", ModVersion{}, false},
+		{"github.com/gowalker/buffer", ModVersion{}, false},
+		{"golang.org/x/text/language", ModVersion{"golang.org/x/text", "v0.3.8"}, true},
+		{"github.com/unknwon/com", ModVersion{"github.com/gowalker/com", "v1.0.0"}, true},
+		{"fmt", ModVersion{}, false},
+	}
+	for _, test := range tests {
+		if got, ok := m.Requirement(test.importPath); got != test.want || ok != test.ok {
+			t.Errorf("Requirement(%q) = %v, %v, want %v, %v", test.importPath, got, ok, test.want, test.ok)
+		}
+	}
+
+	got := importVersions(m, []string{"fmt", "github.com/unknwon/com", "golang.org/x/text/language"})
+	want := "github.com/unknwon/com github.com/gowalker/com@v1.0.0|golang.org/x/text/language golang.org/x/text@v0.3.8"
+	if got != want {
+		t.Errorf("importVersions = %q, want %q", got, want)
+	}
+}
+
+func TestModuleVersionURL(t *testing.T) {
+	tests := []struct {
+		modPath, version, want string
+	}{
+		{"github.com/unknwon/com", "v0.0.0-20190804042917-757f69c95f3e", "https://github.com/unknwon/com/tree/757f69c95f3e"},
+		{"github.com/unknwon/com", "v1.2.4-0.20190804042917-757f69c95f3e", "https://github.com/unknwon/com/tree/757f69c95f3e"},
+		{"github.com/minio/minio-go", "v6.0.14+incompatible", "https://github.com/minio/minio-go/tree/v6.0.14"},
+		{"github.com/gowalker/hello/v2", "v2.1.0", "https://github.com/gowalker/hello/tree/v2.1.0"},
+		{"github.com/gowalker/hello/sub/v3", "v3.0.0", "https://github.com/gowalker/hello/tree/sub/v3.0.0"},
+		{"github.com/gowalker/hello/sub", "v0.1.0", "https://github.com/gowalker/hello/tree/sub/v0.1.0"},
+		{"golang.org/x/text", "v0.3.2", ""},
+		{"github.com/gowalker", "v1.0.0", ""},
+	}
+	for _, test := range tests {
+		if got := ModuleVersionURL(test.modPath, test.version); got != test.want {
+			t.Errorf("ModuleVersionURL(%q, %q) = %q, want %q", test.modPath, test.version, got, test.want)
+		}
+	}
+}
+
+func TestResolveMajorDir(t *testing.T) {
+	hasGoMod := func(dir string) bool { return dir == "v3" }
+	tests := []struct {
+		dir                   string
+		pkgDir, modDir, major string
+	}{
+		{"/v2", "", "", "v2"},
+		{"/v2/sub", "/sub", "", "v2"},
+		{"/sub/v2", "/sub", "sub", "v2"},
+		{"/v3", "/v3", "", ""},
+		{"/v3/sub", "/v3/sub", "", ""},
+		{"/sub", "/sub", "", ""},
+		{"", "", "", ""},
+	}
+	for _, test := range tests {
+		pkgDir, modDir, major := resolveMajorDir(test.dir, hasGoMod)
+		if pkgDir != test.pkgDir || modDir != test.modDir || major != test.major {
+			t.Errorf("resolveMajorDir(%q) = %q, %q, %q, want %q, %q, %q",
+				test.dir, pkgDir, modDir, major, test.pkgDir, test.modDir, test.major)
+		}
+	}
+}
+
+func TestLatestMajorTag(t *testing.T) {
+	tags := []string{"v1.9.0", "v2.0.0", "v2.10.0", "v2.9.1", "v2.11.0-rc.1", "v20.0.0", "sub/v2.3.0", "v3.0.0-beta.2", "v3.0.0-beta.10"}
+	tests := []struct {
+		modDir, major, want string
+	}{
+		{"", "v2", "v2.10.0"},
+		{"", "v3", "v3.0.0-beta.10"},
+		{"sub", "v2", "sub/v2.3.0"},
+		{"", "v4", ""},
+	}
+	for _, test := range tests {
+		if got := latestMajorTag(tags, test.modDir, test.major); got != test.want {
+			t.Errorf("latestMajorTag(%q, %q) = %q, want %q", test.modDir, test.major, got, test.want)
+		}
+	}
+}
+
+func TestNestedModuleDir(t *testing.T) {
+	tests := []struct {
+		pkgDir, goModPath, want string
+		ok                      bool
+	}{
+		{"", "sub/go.mod", "sub", true},
+		{"", "go.mod", "", false},
+		{"", "sub/deep/go.mod", "", false},
+		{"pkg/", "pkg/sub/go.mod", "sub", true},
+		{"pkg/", "other/sub/go.mod", "", false},
+		{"", "sub/go.sum", "", false},
+	}
+	for _, test := range tests {
+		got, ok := nestedModuleDir(test.pkgDir, test.goModPath)
+		if got != test.want || ok != test.ok {
+			t.Errorf("nestedModuleDir(%q, %q) = %q, %v, want %q, %v", test.pkgDir, test.goModPath, got, ok, test.want, test.ok)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"bytes"
+	"go/scanner"
+	"go/token"
+	"html/template"
+	"strings"
+)
+
+// codeToken is a token of Go source code with its offsets.
+type codeToken struct {
+	tok        token.Token
+	start, end int
+}
+
+// scanCode splits src into tokens, automatically inserted semicolons are omitted.
+func scanCode(src string) []codeToken {
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	// Syntax errors are ignored, all text is written as it is anyway.
+	s.Init(file, []byte(src), func(token.Position, string) {}, scanner.ScanComments)
+
+	var tokens []codeToken
+	last := 0
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			return tokens
+		} else if tok == token.SEMICOLON && lit == "\n" {
+			continue
+		}
+
+		start := file.Offset(pos)
+		end := start + len(lit)
+		if len(lit) == 0 {
+			end = start + len(tok.String())
+		}
+		if end > len(src) {
+			end = len(src)
+		}
+		if start < last || start >= end {
+			continue
+		}
+		tokens = append(tokens, codeToken{tok, start, end})
+		last = end
+	}
+}
+
+// codeWriter writes Go source code as highlighted HTML.
+type codeWriter struct {
+	bytes.Buffer
+	links    []*Link
+	comments map[string]string // Escaped documentation of exported names in current package.
+}
+
+func newCodeWriter(links []*Link) *codeWriter {
+	w := &codeWriter{
+		links:    links,
+		comments: make(map[string]string),
+	}
+	for _, l := range links {
+		if len(l.Path) == 0 {
+			w.comments[l.Name] = l.Comment
+		}
+	}
+	return w
+}
+
+// findLink returns link of given name.
+func (w *codeWriter) findLink(name string) *Link {
+	for _, l := range w.links {
+		if l.Name == name {
+			return l
+		}
+	}
+	return nil
+}
+
+func (w *codeWriter) text(s string) {
+	w.WriteString(template.HTMLEscapeString(s))
+}
+
+// space writes text between tokens, tabs are expanded to four spaces.
+func (w *codeWriter) space(s string) {
+	w.text(strings.Replace(s, "\t", "    ", -1))
+}
+
+func (w *codeWriter) span(class, s string) {
+	w.WriteString(`<span class="` + class + `">`)
+	w.text(s)
+	w.WriteString("</span>")
+}
+
+// anchor writes a link, title must be escaped.
+func (w *codeWriter) anchor(class, href, title, s string) {
+	w.WriteString(`<a class="` + class + `"`)
+	if len(title) > 0 {
+		w.WriteString(` title="` + title + `"`)
+	}
+	if !strings.HasPrefix(href, "#") {
+		w.WriteString(` target="_blank"`)
+	}
+	w.WriteString(` href="` + template.HTMLEscapeString(href) + `">`)
+	w.text(s)
+	w.WriteString("</a>")
+}
+
+// internal writes a link to exported name in current package.
+func (w *codeWriter) internal(name, s string) {
+	w.anchor("int", "#"+name, w.comments[name], s)
+}
+
+func (w *codeWriter) predeclared(s string) bool {
+	switch predeclared[s] {
+	case predeclaredConstant:
+		w.span("boo", s)
+	case predeclaredFunction:
+		w.span("bui", s)
+	default:
+		return false
+	}
+	return true
+}
+
+func (w *codeWriter) token(t codeToken, s string) {
+	switch {
+	case t.tok == token.COMMENT:
+		w.span("com", s)
+	case t.tok == token.STRING || t.tok == token.CHAR:
+		w.span("str", s)
+	case t.tok == token.RETURN || t.tok == token.BREAK:
+		w.span("ret", s)
+	case t.tok.IsKeyword():
+		w.span("key", s)
+	default:
+		w.text(s)
+	}
+}
+
+// annotation writes text covered by the annotation.
+func (w *codeWriter) annotation(a Annotation, s string) {
+	switch a.Kind {
+	case ExportLinkAnnotation:
+		name := a.Anchor
+		if len(name) == 0 {
+			name = s[strings.LastIndex(s, ".")+1:]
+		}
+		if len(a.ImportPath) == 0 {
+			w.internal(name, s)
+			return
+		}
+		w.anchor("ext", "/"+a.ImportPath+"#"+name, "", s)
+	case PackageLinkAnnotation:
+		if a.ImportPath == "C" {
+			w.text(s)
+			return
+		}
+		w.anchor("ext", "/"+a.ImportPath, "", s)
+	case AnchorAnnotation:
+		w.WriteString(`<span id="` + template.HTMLEscapeString(s) + `">`)
+		w.text(s)
+		w.WriteString("</span>")
+	case BuiltinAnnotation:
+		if !w.predeclared(s) {
+			w.text(s)
+		}
+	case TypeParamAnnotation:
+		w.anchor("int", "#"+a.Anchor, "", s)
+	default:
+		w.text(s)
+	}
+}
+
+// ident writes identifier of code without annotations and returns number of
+// extra tokens consumed. Identifiers are linked by matching names in links.
+func (w *codeWriter) ident(src string, tokens []codeToken, i int) int {
+	s := src[tokens[i].start:tokens[i].end]
+	// Selected names are never linked by their own.
+	if i > 0 && tokens[i-1].tok == token.PERIOD {
+		w.text(s)
+		return 0
+	}
+
+	// Qualified identifiers of imported packages.
+	if i+2 < len(tokens) && tokens[i+1].tok == token.PERIOD && tokens[i+2].tok == token.IDENT &&
+		tokens[i+1].start == tokens[i].end && tokens[i+2].start == tokens[i+1].end {
+		if l := w.findLink(s + "."); l != nil {
+			name := src[tokens[i+2].start:tokens[i+2].end]
+			if len(l.Path) == 0 {
+				w.internal(name, s+"."+name)
+			} else {
+				w.anchor("ext", "/"+l.Path+"#"+name, "", s+"."+name)
+			}
+			return 2
+		}
+	}
+
+	if w.predeclared(s) {
+		return 0
+	}
+
+	switch l := w.findLink(s); {
+	case l == nil:
+		w.text(s)
+	case len(l.Path) == 0:
+		w.internal(s, s)
+	default:
+		// Dot-imported names.
+		w.anchor("ext", l.Path, "", s)
+	}
+	return 0
+}
+
+// formatCode renders Go source code as highlighted HTML. Identifiers are linked
+// by annotations of the code, or by names in links if there are no annotations.
+// Names dot-imported are always linked by links.
+func formatCode(code Code, links []*Link) string {
+	src := strings.Replace(code.Text, "\r", "", -1)
+	if len(src) == 0 {
+		return ""
+	}
+
+	w := newCodeWriter(links)
+	tokens := scanCode(src)
+	annotated, annotations := len(code.Annotations) > 0, code.Annotations
+	last := 0
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if t.start < last {
+			// Covered by previous annotation.
+			continue
+		}
+		w.space(src[last:t.start])
+		last = t.end
+		s := src[t.start:t.end]
+
+		if t.tok != token.IDENT {
+			w.token(t, s)
+			continue
+		} else if !annotated {
+			i += w.ident(src, tokens, i)
+			last = tokens[i].end
+			continue
+		}
+
+		for len(annotations) > 0 && int(annotations[0].End) <= t.start {
+			annotations = annotations[1:]
+		}
+		if len(annotations) > 0 && int(annotations[0].Pos) == t.start && int(annotations[0].End) <= len(src) {
+			a := annotations[0]
+			w.annotation(a, src[a.Pos:a.End])
+			last = int(a.End)
+			continue
+		}
+
+		if l := w.findLink(s); l != nil && len(l.Path) > 0 {
+			w.anchor("ext", l.Path, "", s)
+		} else {
+			w.text(s)
+		}
+	}
+	w.space(src[last:])
+	return w.String()
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestFormatCode(t *testing.T) {
+	links := []*Link{
+		{Name: "Config", Comment: "Config is &lt;global&gt;."},
+		{Name: "json.", Path: "encoding/json"},
+		{Name: "Node", Path: "/gopkg.in/yaml.v2#Node"},
+	}
+
+	tests := []struct {
+		name string
+		code string
+		want string
+	}{
+		{
+			name: "escape",
+			code: "x := \"<script>\" // </pre><script>alert(1)</script>",
+			want: `x := <span class="str">&#34;&lt;script&gt;&#34;</span> <span class="com">// &lt;/pre&gt;&lt;script&gt;alert(1)&lt;/script&gt;</span>`,
+		},
+		{
+			name: "keywords",
+			code: "go func() { ch <- map[int]chan bool{} }()\nfallthrough\nreturn nil",
+			want: `<span class="key">go</span> <span class="key">func</span>() { ch &lt;- <span class="key">map</span>[int]<span class="key">chan</span> bool{} }()` + "\n" +
+				`<span class="key">fallthrough</span>` + "\n" + `<span class="ret">return</span> <span class="boo">nil</span>`,
+		},
+		{
+			name: "raw string with comment",
+			code: "s := `// not a comment \"`",
+			want: "s := <span class=\"str\">`// not a comment &#34;`</span>",
+		},
+		{
+			name: "runes and escapes",
+			code: `r, s := '"', "\"//\""`,
+			want: `r, s := <span class="str">&#39;&#34;&#39;</span>, <span class="str">&#34;\&#34;//\&#34;&#34;</span>`,
+		},
+		{
+			name: "links",
+			code: "var c Config = json.Marshal(Node, cfg.Config, len(x))",
+			want: `<span class="key">var</span> c <a class="int" title="Config is &lt;global&gt;." href="#Config">Config</a> = ` +
+				`<a class="ext" target="_blank" href="/encoding/json#Marshal">json.Marshal</a>(` +
+				`<a class="ext" target="_blank" href="/gopkg.in/yaml.v2#Node">Node</a>, cfg.Config, <span class="bui">len</span>(x))`,
+		},
+		{
+			name: "tabs",
+			code: "\tx++",
+			want: "    x++",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := formatCode(Code{Text: test.code}, links); got != test.want {
+				t.Errorf("got\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFormatCode_Annotations(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "conf.go", `package conf
+
+import (
+	y "gopkg.in/yaml.v2"
+)
+
+// Config is a configuration.
+type Config[T any] struct {
+	Root  *y.Node // Root <node>.
+	Value T
+	Extra *Config[int]
+}
+
+const Version, Name = "1.0", "</pre>"
+`, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ast.NewPackage(fset, map[string]*ast.File{"conf.go": file}, poorMansImporter, nil)
+	// Doc comments are separated from declarations by go/doc.
+	file.Decls[1].(*ast.GenDecl).Doc = nil
+
+	links := []*Link{{Name: "Config", Comment: "Config is a configuration."}}
+	tests := []struct {
+		decl ast.Decl
+		want string
+	}{
+		{
+			decl: file.Decls[1],
+			want: `<span class="key">type</span> Config[<a class="int" href="#_tp_Config_T">T</a> any] <span class="key">struct</span> {` + "\n" +
+				`    Root  *<a class="ext" target="_blank" href="/gopkg.in/yaml.v2#Node">y.Node</a> <span class="com">// Root &lt;node&gt;.</span>` + "\n" +
+				`    Value <a class="int" href="#_tp_Config_T">T</a>` + "\n" +
+				`    Extra *<a class="int" title="Config is a configuration." href="#Config">Config</a>[int]` + "\n" +
+				`}`,
+		},
+		{
+			decl: file.Decls[2],
+			want: `<span class="key">const</span> <span id="Version">Version</span>, <span id="Name">Name</span> = ` +
+				`<span class="str">&#34;1.0&#34;</span>, <span class="str">&#34;&lt;/pre&gt;&#34;</span>`,
+		},
+	}
+	for _, test := range tests {
+		code, _ := printDecl(test.decl, fset, nil, nil, nil)
+		if got := formatCode(code, links); got != test.want {
+			t.Errorf("got\n%s\nwant\n%s", got, test.want)
+		}
+	}
+	// Objects resolved by type checking are linked to their anchors.
+	code := Code{"c.Root", []Annotation{{Pos: 2, End: 6, Kind: ExportLinkAnnotation, Anchor: "Config.Root"}}}
+	want := `c.<a class="int" href="#Config.Root">Root</a>`
+	if got := formatCode(code, links); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"flag"
+	"path/filepath"
+	"testing"
+
+	"github.com/unknwon/gowalker/internal/httplib"
+	"github.com/unknwon/gowalker/internal/httpreplay"
+)
+
+var record = flag.Bool("record", false, "Record HTTP fixtures from live services instead of replaying them.")
+
+// useFixture routes all outgoing requests of the crawler through fixture
+// file testdata/<name>.txt, it returns a function to restore the transports.
+func useFixture(t *testing.T, name string) func() {
+	path := filepath.Join("testdata", name+".txt")
+
+	var tr *httpreplay.Transport
+	if *record {
+		tr = httpreplay.NewRecorder(path, httpTransport)
+	} else {
+		var err error
+		tr, err = httpreplay.Load(path)
+		if err != nil {
+			t.Fatalf("Load fixture: %v", err)
+		}
+	}
+
+	oldTransport := Client.Transport
+	oldSetting := httplib.DefaultSetting()
+	Client.Transport = tr
+	setting := oldSetting
+	setting.Transport = tr
+	httplib.SetDefaultSetting(setting)
+
+	return func() {
+		Client.Transport = oldTransport
+		httplib.SetDefaultSetting(oldSetting)
+		if err := tr.Save(); err != nil {
+			t.Fatalf("Save fixture: %v", err)
+		}
+	}
+}
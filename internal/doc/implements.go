@@ -0,0 +1,253 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/unknwon/gowalker/internal/db"
+)
+
+// Implementation is a relation between a concrete type and an interface.
+type Implementation struct {
+	Name string `json:"name"` // Qualified name of the interface or the type, e.g. "io.Reader".
+	Path string `json:"path"` // Link of documentation.
+	Ptr  bool   `json:"ptr"`  // Indicates only the pointer type implements the interface.
+}
+
+// wellKnownSrc declares well-known interfaces of standard library whose methods
+// only refer to predeclared types, names are in the form of "<import path>.<name>"
+// with "/" and "." replaced by "_".
+const wellKnownSrc = `package wellknown
+
+type fmt_Stringer interface { String() string }
+type fmt_GoStringer interface { GoString() string }
+type io_Reader interface { Read(p []byte) (n int, err error) }
+type io_Writer interface { Write(p []byte) (n int, err error) }
+type io_Closer interface { Close() error }
+type io_Seeker interface { Seek(offset int64, whence int) (int64, error) }
+type io_ReaderAt interface { ReadAt(p []byte, off int64) (n int, err error) }
+type io_WriterAt interface { WriteAt(p []byte, off int64) (n int, err error) }
+type io_ByteReader interface { ReadByte() (byte, error) }
+type io_ByteWriter interface { WriteByte(c byte) error }
+type io_RuneReader interface { ReadRune() (r rune, size int, err error) }
+type io_StringWriter interface { WriteString(s string) (n int, err error) }
+type encoding_TextMarshaler interface { MarshalText() (text []byte, err error) }
+type encoding_TextUnmarshaler interface { UnmarshalText(text []byte) error }
+type encoding_BinaryMarshaler interface { MarshalBinary() (data []byte, err error) }
+type encoding_BinaryUnmarshaler interface { UnmarshalBinary(data []byte) error }
+type encoding_json_Marshaler interface { MarshalJSON() ([]byte, error) }
+type encoding_json_Unmarshaler interface { UnmarshalJSON([]byte) error }
+type flag_Value interface {
+	String() string
+	Set(string) error
+}
+type sort_Interface interface {
+	Len() int
+	Less(i, j int) bool
+	Swap(i, j int)
+}
+type container_heap_Interface interface {
+	sort_Interface
+	Push(x any)
+	Pop() any
+}
+type hash_Hash interface {
+	io_Writer
+	Sum(b []byte) []byte
+	Reset()
+	Size() int
+	BlockSize() int
+}
+type database_sql_Scanner interface { Scan(src any) error }
+`
+
+// wellKnownIface is a well-known interface.
+type wellKnownIface struct {
+	importPath, name string
+	iface            *types.Interface
+}
+
+var (
+	wellKnownOnce   sync.Once
+	wellKnownIfaces []*wellKnownIface
+)
+
+// wellKnownInterfaces returns well-known interfaces sorted by qualified names.
+func wellKnownInterfaces() []*wellKnownIface {
+	wellKnownOnce.Do(func() {
+		wellKnownIfaces = append(wellKnownIfaces, &wellKnownIface{
+			name:  "error",
+			iface: types.Universe.Lookup("error").Type().Underlying().(*types.Interface),
+		})
+
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, "wellknown.go", wellKnownSrc, 0)
+		if err != nil {
+			panic("parse well-known interfaces: " + err.Error())
+		}
+		pkg, err := new(types.Config).Check("wellknown", fset, []*ast.File{file}, nil)
+		if err != nil {
+			panic("check well-known interfaces: " + err.Error())
+		}
+		for _, name := range pkg.Scope().Names() {
+			i := strings.LastIndex(name, "_")
+			wellKnownIfaces = append(wellKnownIfaces, &wellKnownIface{
+				importPath: strings.Replace(name[:i], "_", "/", -1),
+				name:       name[i+1:],
+				iface:      pkg.Scope().Lookup(name).Type().Underlying().(*types.Interface),
+			})
+		}
+		sort.Slice(wellKnownIfaces, func(i, j int) bool {
+			return wellKnownIfaces[i].qualifiedName() < wellKnownIfaces[j].qualifiedName()
+		})
+	})
+	return wellKnownIfaces
+}
+
+func (i *wellKnownIface) qualifiedName() string {
+	if len(i.importPath) == 0 {
+		return i.name
+	}
+	return i.importPath[strings.LastIndex(i.importPath, "/")+1:] + "." + i.name
+}
+
+// implements reports whether the named type implements the interface, and
+// if only its pointer type does.
+func implements(named *types.Named, iface *types.Interface) (ok, ptr bool) {
+	if types.Implements(named, iface) {
+		return true, false
+	}
+	if types.Implements(types.NewPointer(named), iface) {
+		return true, true
+	}
+	return false, false
+}
+
+// exportedNamedTypes returns exported named types of the package, interfaces
+// with empty method sets, type constraints and generic types are skipped.
+func exportedNamedTypes(pkg *types.Package) (concretes, ifaces []*types.Named) {
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || !obj.Exported() || obj.IsAlias() {
+			continue
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() > 0 {
+			continue
+		}
+
+		if iface, ok := named.Underlying().(*types.Interface); ok {
+			if iface.IsMethodSet() && iface.NumMethods() > 0 {
+				ifaces = append(ifaces, named)
+			}
+			continue
+		}
+		concretes = append(concretes, named)
+	}
+	return concretes, ifaces
+}
+
+// implementations fills interfaces implemented by concrete types and types
+// implementing interfaces of the package, and collects types implementing
+// interfaces of imported packages.
+func (w *Walker) implementations(pkg *types.Package) {
+	concretes, ifaces := exportedNamedTypes(pkg)
+
+	tps := make(map[string]*Type, len(w.Pdoc.Types))
+	for _, t := range w.Pdoc.Types {
+		tps[t.Name] = t
+	}
+
+	for _, iface := range ifaces {
+		if t := tps[iface.Obj().Name()]; t != nil {
+			t.IsInterface = true
+		}
+	}
+
+	for _, named := range concretes {
+		t := tps[named.Obj().Name()]
+		if t == nil {
+			continue
+		}
+		for _, wk := range wellKnownInterfaces() {
+			if wk.importPath == pkg.Path() {
+				continue // Listed as interfaces of the package.
+			}
+			if ok, ptr := implements(named, wk.iface); ok {
+				path := "/builtin#error"
+				if len(wk.importPath) > 0 {
+					path = "/" + wk.importPath + "#" + wk.name
+				}
+				t.Implements = append(t.Implements, &Implementation{wk.qualifiedName(), path, ptr})
+			}
+		}
+
+		for _, iface := range ifaces {
+			ok, ptr := implements(named, iface.Underlying().(*types.Interface))
+			if !ok {
+				continue
+			}
+			t.Implements = append(t.Implements, &Implementation{iface.Obj().Name(), "#" + iface.Obj().Name(), ptr})
+			if it := tps[iface.Obj().Name()]; it != nil {
+				it.ImplementedBy = append(it.ImplementedBy, &Implementation{named.Obj().Name(), "#" + named.Obj().Name(), ptr})
+			}
+		}
+	}
+
+	// Types implementing interfaces of imported packages are indexed to be
+	// shown on pages of these packages.
+	w.Pdoc.Implementors = nil
+	for _, imp := range pkg.Imports() {
+		_, impIfaces := exportedNamedTypes(imp)
+		for _, iface := range impIfaces {
+			for _, named := range concretes {
+				if ok, ptr := implements(named, iface.Underlying().(*types.Interface)); ok {
+					w.Pdoc.Implementors = append(w.Pdoc.Implementors, &db.Implementor{
+						Interface: imp.Path() + "#" + iface.Obj().Name(),
+						TypeName:  named.Obj().Name(),
+						Ptr:       ptr,
+					})
+				}
+			}
+		}
+	}
+}
+
+// GetImplementors returns types of other indexed packages that implement the
+// interface of the package.
+func GetImplementors(importPath, name string) ([]*Implementation, error) {
+	impls, err := db.GetImplementors(importPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*Implementation, len(impls))
+	for i, impl := range impls {
+		list[i] = &Implementation{
+			Name: impl.ImportPath + "." + impl.TypeName,
+			Path: "/" + impl.ImportPath + "#" + impl.TypeName,
+			Ptr:  impl.Ptr,
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/unknwon/gowalker/internal/db"
+	"github.com/unknwon/gowalker/internal/setting"
+)
+
+func TestWellKnownInterfaces(t *testing.T) {
+	names := make(map[string]bool)
+	for _, i := range wellKnownInterfaces() {
+		names[i.qualifiedName()] = true
+	}
+	for _, name := range []string{"error", "fmt.Stringer", "io.Reader", "json.Marshaler", "heap.Interface"} {
+		if !names[name] {
+			t.Errorf("%s is not found in %v", name, names)
+		}
+	}
+}
+
+func TestWalker_Implementations(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer func(old string) { setting.DocsGobPath = old }(setting.DocsGobPath)
+	setting.DocsGobPath = dir + "/"
+	defer func(old bool) { setting.TypeCheck = old }(setting.TypeCheck)
+	setting.TypeCheck = true
+
+	dep := &Package{
+		PkgInfo: &db.PkgInfo{ImportPath: "github.com/gowalker/store"},
+		PkgDecl: &PkgDecl{
+			Files: []*Source{{
+				SrcName: "store.go",
+				SrcData: []byte("package store\n\ntype Getter interface { Get(key string) string }\n"),
+			}},
+		},
+	}
+	if err = saveGob(dep); err != nil {
+		t.Fatal(err)
+	}
+
+	pdoc := buildTestPackage(t, "github.com/gowalker/cache", &Source{SrcName: "cache.go", SrcData: []byte(`package cache
+
+import "github.com/gowalker/store"
+
+var _ store.Getter = (*Cache)(nil)
+
+type Sizer interface { Size() int }
+
+type Cache struct{}
+
+func (c *Cache) Get(key string) string { return "" }
+func (c Cache) Size() int { return 0 }
+func (c Cache) String() string { return "" }
+func (c *Cache) Write(p []byte) (int, error) { return 0, nil }
+
+type Error string
+
+func (e Error) Error() string { return string(e) }
+`)})
+
+	tps := make(map[string]*Type)
+	for _, t := range pdoc.Types {
+		tps[t.Name] = t
+	}
+	wantImpls := []*Implementation{
+		{Name: "fmt.Stringer", Path: "/fmt#Stringer"},
+		{Name: "io.Writer", Path: "/io#Writer", Ptr: true},
+		{Name: "Sizer", Path: "#Sizer"},
+	}
+	if got := tps["Cache"].Implements; !reflect.DeepEqual(got, wantImpls) {
+		t.Errorf("Cache implements: got %v, want %v", got, wantImpls)
+	}
+	if got := tps["Error"].Implements; len(got) != 1 || got[0].Name != "error" {
+		t.Errorf("Error implements: got %v", got)
+	}
+	if !tps["Sizer"].IsInterface || len(tps["Sizer"].ImplementedBy) != 1 || tps["Sizer"].ImplementedBy[0].Name != "Cache" {
+		t.Errorf("Sizer implemented by: got %v", tps["Sizer"].ImplementedBy)
+	}
+
+	wantImplementors := []*db.Implementor{
+		{Interface: "github.com/gowalker/store#Getter", TypeName: "Cache", Ptr: true},
+	}
+	if !reflect.DeepEqual(pdoc.Implementors, wantImplementors) {
+		t.Errorf("Implementors: got %v, want %v", pdoc.Implementors, wantImplementors)
+	}
+}
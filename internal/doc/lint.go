@@ -0,0 +1,324 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"go/doc/comment"
+	"go/parser"
+	"go/token"
+	"path"
+	"strings"
+)
+
+// Kinds of documentation issues.
+const (
+	LintUndocumented  = "undocumented"
+	LintCommentPrefix = "comment_prefix"
+	LintUnusedExample = "unused_example"
+	LintBrokenLink    = "broken_link"
+)
+
+// LintIssue is an issue of documentation of an identifier.
+type LintIssue struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Anchor  string `json:"anchor,omitempty"` // Anchor of the identifier in documentation page.
+	Message string `json:"message,omitempty"`
+}
+
+// LintReport is the documentation quality report of a package.
+type LintReport struct {
+	ImportPath string       `json:"import_path"`
+	Exported   int          `json:"exported"`   // Number of exported identifiers.
+	Documented int          `json:"documented"` // Number of exported identifiers with doc comments.
+	Coverage   float64      `json:"coverage"`   // Percentage of documented identifiers.
+	Issues     []*LintIssue `json:"issues"`
+}
+
+// Count returns the number of issues of given kind.
+func (r *LintReport) Count(kind string) int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// hasSymbol returns true if the package declares the exported identifier,
+// or the method or field of the type when recv is not empty.
+func hasSymbol(pdoc *Package, recv, name string) bool {
+	hasValue := func(vals []*Value) bool {
+		for _, v := range vals {
+			for _, n := range v.Names {
+				if n == name {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	hasFunc := func(fs []*Func) bool {
+		for _, f := range fs {
+			if f.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(recv) == 0 && (hasValue(pdoc.Consts) || hasValue(pdoc.Vars) || hasFunc(pdoc.Funcs)) {
+		return true
+	}
+	for _, t := range pdoc.Types {
+		if len(recv) == 0 {
+			if t.Name == name || hasValue(t.Consts) || hasValue(t.Vars) || hasFunc(t.Funcs) {
+				return true
+			}
+			continue
+		} else if t.Name != recv {
+			continue
+		}
+
+		if hasFunc(t.Methods) {
+			return true
+		}
+		for _, f := range append(t.Fields, t.InterfaceMethods...) {
+			if f.Name == name {
+				return true
+			}
+		}
+		for _, g := range t.Promoted {
+			for _, m := range append(g.Fields, g.Methods...) {
+				if m.Name == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// docLinks returns doc links in the parsed comment.
+func docLinks(blocks []comment.Block) []*comment.DocLink {
+	var links []*comment.DocLink
+	var inText func(text []comment.Text)
+	inText = func(text []comment.Text) {
+		for _, t := range text {
+			switch t := t.(type) {
+			case *comment.DocLink:
+				links = append(links, t)
+			case *comment.Link:
+				inText(t.Text)
+			}
+		}
+	}
+	for _, b := range blocks {
+		switch b := b.(type) {
+		case *comment.Paragraph:
+			inText(b.Text)
+		case *comment.Heading:
+			inText(b.Text)
+		case *comment.List:
+			for _, item := range b.Items {
+				links = append(links, docLinks(item.Content)...)
+			}
+		}
+	}
+	return links
+}
+
+// linter checks documentation of a package.
+type linter struct {
+	pdoc   *Package
+	report *LintReport
+	parser *comment.Parser
+	deps   map[string]*Package // Nil value means the package is not indexed.
+}
+
+func newLinter(pdoc *Package) *linter {
+	l := &linter{
+		pdoc:   pdoc,
+		report: &LintReport{ImportPath: pdoc.ImportPath},
+		deps:   make(map[string]*Package),
+	}
+	l.parser = &comment.Parser{
+		LookupPackage: lookupPackage(pdoc),
+		// Accept all symbols so that the unresolved ones can be reported.
+		LookupSym: func(recv, name string) bool { return true },
+	}
+	return l
+}
+
+func (l *linter) add(kind, name, anchor, message string) {
+	l.report.Issues = append(l.report.Issues, &LintIssue{
+		Kind:    kind,
+		Name:    name,
+		Anchor:  anchor,
+		Message: message,
+	})
+}
+
+// dep returns the stored documentation of the package, or nil if it is not indexed.
+func (l *linter) dep(importPath string) *Package {
+	pdoc, ok := l.deps[importPath]
+	if !ok {
+		pdoc, _ = loadGob(importPath)
+		l.deps[importPath] = pdoc
+	}
+	return pdoc
+}
+
+// links reports doc links in the comment that cannot be resolved, links to
+// packages that are not indexed are not checked.
+func (l *linter) links(name, anchor, text string) {
+	for _, link := range docLinks(l.parser.Parse(text).Content) {
+		pdoc := l.pdoc
+		if len(link.ImportPath) > 0 {
+			if pdoc = l.dep(link.ImportPath); pdoc == nil {
+				continue
+			}
+		}
+		// Lower-cased names are mostly not meant to be links, e.g. "[n]int".
+		if len(link.Name) == 0 || !token.IsExported(link.Name) || hasSymbol(pdoc, link.Recv, link.Name) {
+			continue
+		}
+
+		target := link.Name
+		if len(link.Recv) > 0 {
+			target = link.Recv + "." + target
+		}
+		if len(link.ImportPath) > 0 {
+			target = path.Base(link.ImportPath) + "." + target
+		}
+		l.add(LintBrokenLink, name, anchor, "["+target+"]")
+	}
+}
+
+// check checks the doc comment of an exported identifier, the comment should
+// start with one of the prefixes.
+func (l *linter) check(name, anchor, text string, prefixes ...string) {
+	l.report.Exported++
+	if len(strings.TrimSpace(text)) == 0 {
+		l.add(LintUndocumented, name, anchor, "")
+		return
+	}
+	l.report.Documented++
+
+	if len(prefixes) > 0 {
+		ok := false
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(text, prefix+" ") {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			l.add(LintCommentPrefix, name, anchor, "comment should start with \""+prefixes[0]+" ...\"")
+		}
+	}
+	l.links(name, anchor, text)
+}
+
+func (l *linter) values(vals []*Value, anchor string) {
+	for _, v := range vals {
+		var prefixes []string
+		if len(v.Names) == 1 {
+			prefixes = []string{v.Names[0]}
+		}
+		// Names in a group share the doc comment of the group.
+		for _, name := range v.Names {
+			if token.IsExported(name) {
+				l.check(name, anchor, v.Doc, prefixes...)
+			}
+		}
+	}
+}
+
+func (l *linter) funcs(fs []*Func, recv string) {
+	for _, f := range fs {
+		name, anchor := f.Name, f.Name
+		if len(recv) > 0 {
+			name, anchor = recv+"."+f.Name, recv+"_"+f.Name
+		}
+		l.check(name, anchor, f.Doc, f.Name)
+	}
+}
+
+// packageName returns the name of package declared in its source files, which
+// may differ from the last element of import path.
+func packageName(pdoc *Package) string {
+	if len(pdoc.Files) > 0 {
+		file, err := parser.ParseFile(token.NewFileSet(), pdoc.Files[0].SrcName, pdoc.Files[0].Data(), parser.PackageClauseOnly)
+		if err == nil {
+			return file.Name.Name
+		}
+	}
+	return path.Base(pdoc.ImportPath)
+}
+
+// lint returns the documentation quality report of the package. Examples of
+// the package are assigned to declarations in place.
+func lint(pdoc *Package) *LintReport {
+	l := newLinter(pdoc)
+
+	name := packageName(pdoc)
+	if pdoc.IsCmd {
+		// Comments of commands are free-form.
+		l.check("package "+name, "", pdoc.RawDoc)
+	} else {
+		l.check("package "+name, "", pdoc.RawDoc, "Package "+name)
+	}
+
+	l.values(pdoc.Consts, "_constants")
+	l.values(pdoc.Vars, "_variables")
+	l.funcs(pdoc.Funcs, "")
+	for _, t := range pdoc.Types {
+		l.check(t.Name, t.Name, t.Doc, t.Name, "A "+t.Name, "An "+t.Name, "The "+t.Name)
+		l.values(t.Consts, t.Name)
+		l.values(t.Vars, t.Name)
+		l.funcs(t.Funcs, "")
+		l.funcs(t.Methods, t.Name)
+		for _, f := range append(t.Fields, t.InterfaceMethods...) {
+			l.links(t.Name+"."+f.Name, FieldAnchor(t.Name, f.Name), f.Doc)
+		}
+	}
+
+	assignExamples(pdoc)
+	for _, ex := range pdoc.Examples {
+		if !ex.IsUsed {
+			l.add(LintUnusedExample, "Example"+ex.Name, "_ex_"+ex.Name,
+				"example does not refer to any exported identifier")
+		}
+	}
+
+	r := l.report
+	r.Coverage = 100
+	if r.Exported > 0 {
+		r.Coverage = float64(r.Documented) * 100 / float64(r.Exported)
+	}
+	return r
+}
+
+// Lint returns the documentation quality report of given package, the package
+// must have its gob file stored.
+func Lint(importPath string) (*LintReport, error) {
+	pdoc, err := loadGob(importPath)
+	if err != nil {
+		return nil, err
+	}
+	return lint(pdoc), nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	pdoc := buildTestPackage(t, "github.com/gowalker/go-cache",
+		&Source{SrcName: "cache.go", SrcData: []byte(`// Package cache is an in-memory cache, see [Cache] and [Missing].
+package cache
+
+// Size limits.
+const (
+	MinSize = 1
+	MaxSize = 10
+)
+
+var Default *Cache
+
+// A Cache stores items, use [Cache.Get] and [Cache.Fetch] to read them.
+type Cache struct{}
+
+// New creates a new [Cache] of [strings.Builder].
+func New() *Cache { return nil }
+
+// Returns the item.
+func (c *Cache) Get(key string) string { return "" }
+
+func (c *Cache) Set(key, val string) {}
+`)},
+		&Source{SrcName: "example_test.go", SrcData: []byte(`package cache_test
+
+func ExampleNew() {}
+
+func ExampleOpen() {}
+`)},
+	)
+
+	r := lint(pdoc)
+	want := []*LintIssue{
+		{Kind: LintBrokenLink, Name: "package cache", Message: "[Missing]"},
+		{Kind: LintBrokenLink, Name: "Cache", Anchor: "Cache", Message: "[Cache.Fetch]"},
+		{Kind: LintUndocumented, Name: "Default", Anchor: "Cache"},
+		{Kind: LintCommentPrefix, Name: "Cache.Get", Anchor: "Cache_Get", Message: `comment should start with "Get ..."`},
+		{Kind: LintUndocumented, Name: "Cache.Set", Anchor: "Cache_Set"},
+		{Kind: LintUnusedExample, Name: "ExampleOpen", Anchor: "_ex_Open", Message: "example does not refer to any exported identifier"},
+	}
+	if !reflect.DeepEqual(r.Issues, want) {
+		for _, issue := range r.Issues {
+			t.Logf("%+v", *issue)
+		}
+		t.Fatal("unexpected issues")
+	}
+	if r.Exported != 8 || r.Documented != 6 || r.Coverage != 75 {
+		t.Errorf("got %d/%d documented and coverage %.1f", r.Documented, r.Exported, r.Coverage)
+	}
+}
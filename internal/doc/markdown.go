@@ -0,0 +1,346 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// mdWriter writes documentation of a package in GitHub-flavoured Markdown.
+type mdWriter struct {
+	bytes.Buffer
+	pdoc   *Package
+	scheme string
+	r      *docRenderer
+}
+
+// comment writes doc comment as Markdown, headings in it are nested under given level.
+func (w *mdWriter) comment(text string, headingLevel int) {
+	if len(text) == 0 {
+		return
+	}
+	w.Write(w.r.markdown(text, headingLevel))
+	w.WriteString("\n")
+}
+
+func (w *mdWriter) code(lang, code string) {
+	code = strings.TrimRight(code, "\n")
+	// Use a longer fence when the code contains one.
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	fmt.Fprintf(w, "%s%s\n%s\n%s\n\n", fence, lang, code, fence)
+}
+
+// url returns absolute URL of source link, links to source viewer are kept as they are.
+func (w *mdWriter) url(u string) string {
+	if strings.HasPrefix(u, "/") {
+		return u
+	}
+	return w.scheme + "://" + u
+}
+
+// heading writes heading with an anchor and a source link if URL is not empty.
+func (w *mdWriter) heading(level int, anchor, prefix, name, url string) {
+	fmt.Fprintf(w, "%s <a name=\"%s\"></a>%s", strings.Repeat("#", level), anchor, prefix)
+	if len(url) > 0 {
+		fmt.Fprintf(w, "[%s](%s)\n\n", name, w.url(url))
+	} else {
+		fmt.Fprintf(w, "%s\n\n", name)
+	}
+}
+
+func (w *mdWriter) examples(level int, exs []*Example) {
+	for _, ex := range exs {
+		name := ex.Name
+		if len(name) == 0 {
+			name = "Package"
+		}
+		fmt.Fprintf(w, "%s <a name=\"_ex_%s\"></a>Example (%s)\n\n", strings.Repeat("#", level), ex.Name, name)
+		w.comment(ex.Doc, level+1)
+		w.code("go", ex.Code)
+		if len(ex.Output) > 0 {
+			w.WriteString("Output:\n\n")
+			w.code("", ex.Output)
+		}
+	}
+}
+
+// platforms writes platforms that the declaration exists on.
+func (w *mdWriter) platforms(ps []string) {
+	if len(ps) > 0 {
+		fmt.Fprintf(w, "*Only on %s.*\n\n", strings.Join(ps, ", "))
+	}
+}
+
+func (w *mdWriter) typeParams(tps []*TypeParam) {
+	if len(tps) == 0 {
+		return
+	}
+	w.WriteString("Type parameters:\n\n")
+	for _, tp := range tps {
+		fmt.Fprintf(w, "- <a name=\"%s\"></a>`%s` `%s`\n", tp.Anchor, tp.Name, tp.Constraint)
+	}
+	w.WriteString("\n")
+}
+
+// fields writes fields of struct or methods of interface with their anchors.
+func (w *mdWriter) fields(t *Type) {
+	for _, list := range []struct {
+		title  string
+		fields []*Field
+	}{
+		{"Fields", t.Fields},
+		{"Methods", t.InterfaceMethods},
+	} {
+		if len(list.fields) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "%s:\n\n", list.title)
+		for _, f := range list.fields {
+			decl := f.Name + " " + f.Type
+			if list.title == "Methods" {
+				decl = f.Name + f.Type
+			}
+			if f.Embedded {
+				decl = f.Type
+			}
+			fmt.Fprintf(w, "- <a name=\"%s\"></a>`%s`", FieldAnchor(t.Name, f.Name), decl)
+			for i, v := range f.TagValues {
+				if len(v) > 0 {
+					fmt.Fprintf(w, " `%s:%q`", t.TagKeys[i], v)
+				}
+			}
+			if doc := strings.Join(strings.Fields(f.Doc), " "); len(doc) > 0 {
+				w.WriteString(": " + doc)
+			}
+			w.WriteString("\n")
+		}
+		w.WriteString("\n")
+	}
+}
+
+// flags writes the table of command-line flags of command.
+func (w *mdWriter) flags(flags []*Flag) {
+	if len(flags) == 0 {
+		return
+	}
+	w.WriteString("## <a name=\"_flags\"></a>Flags\n\n")
+	w.WriteString("| Flag | Type | Default | Usage |\n| --- | --- | --- | --- |\n")
+	for _, f := range flags {
+		def := ""
+		if len(f.Default) > 0 {
+			def = "`" + f.Default + "`"
+		}
+		usage := strings.Replace(strings.Join(strings.Fields(f.Usage), " "), "|", "\\|", -1)
+		fmt.Fprintf(w, "| `-%s` | `%s` | %s | %s |\n", f.Name, f.Type, def, usage)
+	}
+	w.WriteString("\n")
+}
+
+// promoted writes fields and methods promoted from embedded types.
+func (w *mdWriter) promoted(t *Type) {
+	for _, g := range t.Promoted {
+		fmt.Fprintf(w, "Promoted from `%s`:\n\n", g.From)
+		for _, m := range append(g.Fields, g.Methods...) {
+			if len(m.Path) > 0 {
+				fmt.Fprintf(w, "- [`%s`](%s)\n", m.Decl, m.Path)
+			} else {
+				fmt.Fprintf(w, "- `%s`\n", m.Decl)
+			}
+		}
+		w.WriteString("\n")
+	}
+}
+
+func (w *mdWriter) values(vals []*Value) {
+	for _, v := range vals {
+		w.platforms(v.Platforms)
+		w.code("go", v.Decl)
+		w.comment(v.Doc, 4)
+	}
+}
+
+func (w *mdWriter) funcs(level int, typeName string, fs []*Func) {
+	for _, f := range fs {
+		anchor, prefix := f.Name, "func "
+		if len(typeName) > 0 && f.FullName != f.Name {
+			anchor, prefix = f.FullName, "func ("+typeName+") "
+		}
+		w.heading(level, anchor, prefix, f.Name, f.URL)
+		w.platforms(f.Platforms)
+		w.code("go", f.Decl)
+		w.comment(f.Doc, level+1)
+		w.typeParams(f.TypeParams)
+		w.examples(level+1, f.Examples)
+	}
+}
+
+// index writes the list of links to top-level declarations.
+func (w *mdWriter) index() {
+	pdoc := w.pdoc
+	w.WriteString("## Index\n\n")
+	if len(pdoc.Consts) > 0 {
+		w.WriteString("- [Constants](#_constants)\n")
+	}
+	if len(pdoc.Vars) > 0 {
+		w.WriteString("- [Variables](#_variables)\n")
+	}
+	for _, f := range pdoc.Funcs {
+		fmt.Fprintf(w, "- [%s](#%s)\n", f.Decl, f.Name)
+	}
+	for _, t := range pdoc.Types {
+		fmt.Fprintf(w, "- [type %s](#%s)\n", t.Name, t.Name)
+		for _, f := range t.Funcs {
+			fmt.Fprintf(w, "  - [%s](#%s)\n", f.Decl, f.Name)
+		}
+		for _, m := range t.Methods {
+			fmt.Fprintf(w, "  - [%s](#%s)\n", m.Decl, m.FullName)
+		}
+	}
+	for _, s := range noteSections(pdoc.Notes) {
+		fmt.Fprintf(w, "- [%s](#_notes_%s)\n", s.Title, s.Marker)
+	}
+	w.WriteString("\n")
+
+	if len(pdoc.Examples) > 0 {
+		w.WriteString("### Examples\n\n")
+		for _, ex := range pdoc.Examples {
+			name := ex.Name
+			if len(name) == 0 {
+				name = "Package"
+			}
+			fmt.Fprintf(w, "- [%s](#_ex_%s)\n", name, ex.Name)
+		}
+		w.WriteString("\n")
+	}
+}
+
+// assignExamples assigns examples to declarations in the same way as HTML
+// documentation, examples that are not used are marked by Example.IsUsed.
+func assignExamples(pdoc *Package) {
+	for _, f := range pdoc.Funcs {
+		f.Examples = getExamples(pdoc, "", f.Name)
+	}
+	for _, t := range pdoc.Types {
+		for _, f := range t.Funcs {
+			f.Examples = getExamples(pdoc, "", f.Name)
+			f.FullName = f.Name
+		}
+		for _, m := range t.Methods {
+			m.Examples = getExamples(pdoc, t.Name, m.Name)
+			m.FullName = t.Name + "_" + m.Name
+		}
+		t.Examples = getExamples(pdoc, "", t.Name)
+	}
+}
+
+// renderMarkdown renders the documentation of package in GitHub-flavoured Markdown.
+// Examples of given package are assigned to declarations in place, so it must be only
+// rendered once.
+func renderMarkdown(pdoc *Package) []byte {
+	w := &mdWriter{
+		pdoc:   pdoc,
+		scheme: "http",
+		r:      newDocRenderer(pdoc),
+	}
+	// GitHub redirects non-HTTPS link and loses "#XXX".
+	if strings.HasPrefix(pdoc.ProjectPath, "github") {
+		w.scheme = "https"
+	}
+
+	fmt.Fprintf(w, "# %s\n\n", path.Base(pdoc.ImportPath))
+	if pdoc.IsCmd {
+		w.code("sh", "go install "+pdoc.ImportPath+"@latest")
+	} else {
+		w.code("go", fmt.Sprintf("import %q", pdoc.ImportPath))
+	}
+	if len(pdoc.RawDoc) > 0 {
+		w.comment(pdoc.RawDoc, 3)
+	} else if len(pdoc.Synopsis) > 0 {
+		w.WriteString(pdoc.Synopsis + "\n\n")
+	}
+
+	w.flags(pdoc.Flags)
+
+	// Leave the unused examples at package level.
+	assignExamples(pdoc)
+
+	w.index()
+
+	if len(pdoc.Consts) > 0 {
+		w.WriteString("## <a name=\"_constants\"></a>Constants\n\n")
+		w.values(pdoc.Consts)
+	}
+	if len(pdoc.Vars) > 0 {
+		w.WriteString("## <a name=\"_variables\"></a>Variables\n\n")
+		w.values(pdoc.Vars)
+	}
+
+	w.funcs(2, "", pdoc.Funcs)
+
+	for _, t := range pdoc.Types {
+		w.heading(2, t.Name, "type ", t.Name, t.URL)
+		w.platforms(t.Platforms)
+		w.code("go", t.Decl)
+		w.comment(t.Doc, 3)
+		w.fields(t)
+		w.typeParams(t.TypeParams)
+		w.examples(3, t.Examples)
+		w.values(t.Consts)
+		w.values(t.Vars)
+		w.funcs(3, t.Name, t.Funcs)
+		w.funcs(3, t.Name, t.Methods)
+		w.promoted(t)
+	}
+
+	for _, s := range noteSections(pdoc.Notes) {
+		fmt.Fprintf(w, "## <a name=\"_notes_%s\"></a>%s\n\n", s.Marker, s.Title)
+		for _, n := range s.Notes {
+			fmt.Fprintf(w, "- **%s**: %s", n.UID, strings.Replace(n.Body, "\n", " ", -1))
+			if len(n.URL) > 0 {
+				fmt.Fprintf(w, " ([source](%s))", w.url(n.URL))
+			}
+			w.WriteString("\n")
+		}
+		w.WriteString("\n")
+	}
+
+	var unused []*Example
+	for _, ex := range pdoc.Examples {
+		if !ex.IsUsed {
+			unused = append(unused, ex)
+		}
+	}
+	if len(unused) > 0 {
+		w.WriteString("## <a name=\"_exams\"></a>Examples\n\n")
+		w.examples(3, unused)
+	}
+	return w.Bytes()
+}
+
+// RenderMarkdown returns documentation of given package in GitHub-flavoured
+// Markdown, the package must have its gob file stored.
+func RenderMarkdown(importPath string) ([]byte, error) {
+	pdoc, err := loadGob(importPath)
+	if err != nil {
+		return nil, err
+	}
+	return renderMarkdown(pdoc), nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	defer useFixture(t, "github")()
+
+	pdoc, err := getStatic("github.com/gowalker/hello", "")
+	if err != nil {
+		t.Fatalf("getStatic: %v", err)
+	}
+	md := string(renderMarkdown(pdoc))
+
+	for _, want := range []string{
+		"# hello\n\n```go\nimport \"github.com/gowalker/hello\"\n```\n\nPackage hello greets people.",
+		"## Index\n\n- [Constants](#_constants)\n",
+		"  - [func (g *Greeter) Greet(w io.Writer, name string) error](#Greeter_Greet)\n",
+		"## <a name=\"Greeter\"></a>type [Greeter](https://github.com/gowalker/hello/blob/master/hello.go#L",
+		"### <a name=\"Greeter_Greet\"></a>func (Greeter) [Greet](https://github.com/gowalker/hello/blob/master/hello.go#L25)\n\n```go\nfunc (g *Greeter) Greet(w io.Writer, name string) error\n```",
+		"Example (Greet)",
+		"Output:\n\n```\nHello, Go Walker!\n```",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Missing %q in:\n%s", want, md)
+		}
+	}
+}
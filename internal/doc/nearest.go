@@ -0,0 +1,84 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"strings"
+
+	"github.com/unknwon/com"
+
+	"github.com/unknwon/gowalker/internal/base"
+)
+
+// parentFileKind returns the kind of file that applies to packages in its
+// subdirectories, or empty string for other files.
+func parentFileKind(name string) string {
+	switch {
+	case name == "go.mod":
+		return "go.mod"
+	case base.IsLicenseFile(name):
+		return "license"
+	}
+	return ""
+}
+
+// isParentFile returns true if the file applies to packages in its subdirectories.
+func isParentFile(name string) bool {
+	return len(parentFileKind(name)) > 0
+}
+
+// nearestFiles collects files that apply to a package but may be in its parent
+// directories. For each kind of files, the ones in the package directory or the
+// nearest parent directory that has any are kept.
+type nearestFiles struct {
+	pkgDir string // Relative to repository root, with trailing slash unless it is the root.
+	dirs   map[string]string
+	files  map[string][]com.RawFile
+}
+
+func newNearestFiles(pkgDir string) *nearestFiles {
+	return &nearestFiles{
+		pkgDir: pkgDir,
+		dirs:   make(map[string]string),
+		files:  make(map[string][]com.RawFile),
+	}
+}
+
+// add adds the file in given directory, files in directories other than
+// the package directory and its parents are ignored.
+func (s *nearestFiles) add(dir string, f *Source) {
+	kind := parentFileKind(f.SrcName)
+	if len(kind) == 0 || !strings.HasPrefix(s.pkgDir, dir) {
+		return
+	}
+
+	files := s.files[kind]
+	switch {
+	case len(files) > 0 && len(dir) < len(s.dirs[kind]):
+		return
+	case len(files) == 0 || len(dir) > len(s.dirs[kind]):
+		s.dirs[kind], files = dir, nil
+	}
+	s.files[kind] = append(files, f)
+}
+
+// all returns collected files of all kinds.
+func (s *nearestFiles) all() []com.RawFile {
+	var files []com.RawFile
+	for _, kind := range []string{"go.mod", "license"} {
+		files = append(files, s.files[kind]...)
+	}
+	return files
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"testing"
+)
+
+func TestNearestFiles(t *testing.T) {
+	s := newNearestFiles("a/b/")
+	for _, f := range []struct{ dir, name string }{
+		{"", "go.mod"},
+		{"", "LICENSE"},
+		{"a/", "LICENSE-MIT"},
+		{"a/", "COPYING"},
+		{"", "COPYING"},
+		{"a/b/c/", "go.mod"},
+		{"ab/", "LICENSE"},
+		{"a/b/", "README.md"},
+	} {
+		s.add(f.dir, &Source{SrcName: f.name, BrowseUrl: f.dir + f.name})
+	}
+
+	var names []string
+	for _, f := range s.all() {
+		names = append(names, f.(*Source).BrowseUrl)
+	}
+	want := []string{"go.mod", "a/LICENSE-MIT", "a/COPYING"}
+	if len(names) != len(want) {
+		t.Fatalf("all() = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("all() = %v, want %v", names, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"errors"
+	"go/ast"
+	"go/build"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// mergeStrings returns sorted union of a and b.
+func mergeStrings(a, b []string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var merged []string
+	for _, s := range append(append([]string{}, a...), b...) {
+		if !seen[s] {
+			seen[s] = true
+			merged = append(merged, s)
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+// importDir imports the package for every platform and returns the union of
+// them. It records platforms that each Go file is built for.
+func (w *Walker) importDir(ctxt build.Context, platforms []string) (*build.Package, error) {
+	var bpkg *build.Package
+	w.filePlatforms = make(map[string][]string)
+	for _, platform := range platforms {
+		i := strings.Index(platform, "/")
+		if i == -1 {
+			continue
+		}
+		ctxt.GOOS, ctxt.GOARCH = platform[:i], platform[i+1:]
+
+		p, err := ctxt.ImportDir(w.Pdoc.ImportPath, 0)
+		// Continue if there are no Go source files; we still want the directory info.
+		if err != nil {
+			if _, nogo := err.(*build.NoGoError); !nogo {
+				return nil, errors.New("ImportDir: " + err.Error())
+			}
+		}
+
+		goFiles := append(p.GoFiles, p.CgoFiles...)
+		if len(goFiles) > 0 {
+			w.Pdoc.Platforms = append(w.Pdoc.Platforms, platform)
+		}
+		for _, name := range goFiles {
+			w.filePlatforms[name] = append(w.filePlatforms[name], platform)
+		}
+
+		if bpkg == nil {
+			bpkg = p
+			continue
+		}
+		if len(bpkg.Name) == 0 {
+			bpkg.Name, bpkg.Doc = p.Name, p.Doc
+		}
+		bpkg.GoFiles = mergeStrings(bpkg.GoFiles, p.GoFiles)
+		bpkg.CgoFiles = mergeStrings(bpkg.CgoFiles, p.CgoFiles)
+		bpkg.TestGoFiles = mergeStrings(bpkg.TestGoFiles, p.TestGoFiles)
+		bpkg.XTestGoFiles = mergeStrings(bpkg.XTestGoFiles, p.XTestGoFiles)
+		bpkg.Imports = mergeStrings(bpkg.Imports, p.Imports)
+		bpkg.TestImports = mergeStrings(bpkg.TestImports, p.TestImports)
+	}
+	if bpkg == nil {
+		return nil, errors.New("no valid platform")
+	}
+	return bpkg, nil
+}
+
+// funcKey returns the key of function in platforms of declarations.
+func funcKey(decl *ast.FuncDecl) string {
+	if recv, _ := recvTypeParams(decl.Recv); len(recv) > 0 {
+		return recv + "." + decl.Name.Name
+	}
+	return decl.Name.Name
+}
+
+// collectDeclPlatforms records platforms of functions and types declared in files.
+// The same name may be declared by files of different platforms.
+func (w *Walker) collectDeclPlatforms(files map[string]*ast.File) {
+	w.declPlatforms = make(map[string]map[string]bool)
+	add := func(key, name string) {
+		if w.declPlatforms[key] == nil {
+			w.declPlatforms[key] = make(map[string]bool)
+		}
+		for _, platform := range w.filePlatforms[name] {
+			w.declPlatforms[key][platform] = true
+		}
+	}
+
+	for name, file := range files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				add(funcKey(decl), name)
+			case *ast.GenDecl:
+				if decl.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range decl.Specs {
+					add(spec.(*ast.TypeSpec).Name.Name, name)
+				}
+			}
+		}
+	}
+}
+
+// platforms returns given platforms in the order of the package platforms,
+// or nil if the declaration exists on all of them.
+func (w *Walker) platforms(set map[string]bool) []string {
+	if len(set) == 0 || len(set) == len(w.Pdoc.Platforms) {
+		return nil
+	}
+	platforms := make([]string, 0, len(set))
+	for _, platform := range w.Pdoc.Platforms {
+		if set[platform] {
+			platforms = append(platforms, platform)
+		}
+	}
+	return platforms
+}
+
+// declPlatformsOf returns platforms of function or type by its key.
+func (w *Walker) declPlatformsOf(key string) []string {
+	return w.platforms(w.declPlatforms[key])
+}
+
+// posPlatforms returns platforms of the file at given position.
+func (w *Walker) posPlatforms(pos token.Pos) []string {
+	set := make(map[string]bool)
+	for _, platform := range w.filePlatforms[w.Fset.Position(pos).Filename] {
+		set[platform] = true
+	}
+	return w.platforms(set)
+}
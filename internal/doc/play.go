@@ -0,0 +1,200 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/unknwon/gowalker/internal/db"
+	"github.com/unknwon/gowalker/internal/httplib"
+	"github.com/unknwon/gowalker/internal/runner"
+	"github.com/unknwon/gowalker/internal/setting"
+)
+
+var (
+	ErrRunnerDisabled   = errors.New("running examples is disabled")
+	ErrExampleNotFound  = errors.New("example not found")
+	ErrExampleNotPlayed = errors.New("example is not a complete program")
+)
+
+// ExampleResult is the result of running an example.
+type ExampleResult struct {
+	Output   string `json:"output"`
+	Errors   string `json:"errors"`
+	TimedOut bool   `json:"timed_out"`
+	// Indicates the output no longer matches the "Output:" comment of the example.
+	Mismatch bool `json:"mismatch"`
+}
+
+// outputMatches returns true if got matches the expected output of the example
+// in the same way as "go test".
+func outputMatches(got string, e *Example) bool {
+	got, want := strings.TrimSpace(got), strings.TrimSpace(e.Output)
+	if e.Unordered {
+		gotLines, wantLines := strings.Split(got, "\n"), strings.Split(want, "\n")
+		sort.Strings(gotLines)
+		sort.Strings(wantLines)
+		got, want = strings.Join(gotLines, "\n"), strings.Join(wantLines, "\n")
+	}
+	return got == want
+}
+
+// MaxExampleResults is the maximum number of cached results of running examples,
+// the least recently used ones are evicted when it is exceeded.
+const MaxExampleResults = 200
+
+// exampleResult is a cached result of running an example, it is locked while
+// the example is running so that concurrent requests wait for the same run.
+type exampleResult struct {
+	sync.Mutex
+	key      string
+	etag     string
+	result   *ExampleResult
+	lastUsed time.Time // Guarded by exampleResults.
+}
+
+var exampleResults = struct {
+	sync.Mutex
+	m map[string]*exampleResult // Keyed by import path and example name.
+}{m: make(map[string]*exampleResult)}
+
+// getExampleResult returns the cached result of key, a new one is added if it
+// does not exist, and the least recently used one is evicted if the cache is full.
+func getExampleResult(key string) *exampleResult {
+	exampleResults.Lock()
+	defer exampleResults.Unlock()
+
+	r := exampleResults.m[key]
+	if r == nil {
+		if len(exampleResults.m) >= MaxExampleResults {
+			var oldest *exampleResult
+			for _, v := range exampleResults.m {
+				if oldest == nil || v.lastUsed.Before(oldest.lastUsed) {
+					oldest = v
+				}
+			}
+			delete(exampleResults.m, oldest.key)
+		}
+
+		r = &exampleResult{key: key}
+		exampleResults.m[key] = r
+	}
+	r.lastUsed = time.Now()
+	return r
+}
+
+// removeExampleResult removes the result from cache if it is still cached.
+func removeExampleResult(r *exampleResult) {
+	exampleResults.Lock()
+	defer exampleResults.Unlock()
+	if exampleResults.m[r.key] == r {
+		delete(exampleResults.m, r.key)
+	}
+}
+
+// runExample sends the example to the runner service.
+func runExample(pdoc *Package, e *Example) (*ExampleResult, error) {
+	req := &runner.Request{
+		ImportPath: pdoc.ImportPath,
+		Program:    e.Play,
+	}
+	// Standard library is provided by the toolchain of runner.
+	if !pdoc.IsGoRepo {
+		req.Files = make(map[string]string, len(pdoc.Files))
+		for _, f := range pdoc.Files {
+			req.Files[f.SrcName] = string(f.SrcData)
+		}
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	// Allow extra time for building the program.
+	timeout := time.Duration(setting.Runner.Timeout)*time.Second + 3*time.Minute
+	data, err := httplib.Post("http://"+setting.Runner.Addr+"/run").
+		SetTimeout(10*time.Second, timeout).
+		Header("Content-Type", "application/json").
+		Body(body).
+		Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("request runner: %v", err)
+	}
+	var resp runner.Response
+	if err = json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("runner: %s", bytes.TrimSpace(data))
+	}
+
+	result := &ExampleResult{
+		Output:   resp.Output,
+		Errors:   resp.Errors,
+		TimedOut: resp.TimedOut,
+	}
+	if len(e.Output) > 0 {
+		result.Mismatch = resp.TimedOut || resp.ExitCode != 0 || !outputMatches(resp.Output, e)
+	}
+	return result, nil
+}
+
+// runPackageExample finds the example in stored gob file of the package and runs it.
+func runPackageExample(importPath, name string) (*ExampleResult, error) {
+	pdoc, err := loadGob(importPath)
+	if err != nil {
+		return nil, err
+	}
+	var e *Example
+	for _, ex := range pdoc.Examples {
+		if ex.Name == name {
+			e = ex
+			break
+		}
+	}
+	if e == nil {
+		return nil, ErrExampleNotFound
+	} else if len(e.Play) == 0 {
+		return nil, ErrExampleNotPlayed
+	}
+	return runExample(pdoc, e)
+}
+
+// RunExample runs the example of the package by its name via the runner service.
+// Results are cached until the package is updated.
+func RunExample(pinfo *db.PkgInfo, name string) (*ExampleResult, error) {
+	if !setting.Runner.Enabled {
+		return nil, ErrRunnerDisabled
+	}
+
+	cached := getExampleResult(pinfo.ImportPath + "#" + name)
+	cached.Lock()
+	defer cached.Unlock()
+	if cached.result != nil && cached.etag == pinfo.Etag {
+		return cached.result, nil
+	}
+
+	result, err := runPackageExample(pinfo.ImportPath, name)
+	if err != nil {
+		removeExampleResult(cached)
+		return nil, err
+	}
+	cached.etag, cached.result = pinfo.Etag, result
+	return result, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestOutputMatches(t *testing.T) {
+	tests := []struct {
+		got     string
+		example *Example
+		want    bool
+	}{
+		{"hello\n", &Example{Output: "hello"}, true},
+		{"  hello\nworld\n\n", &Example{Output: "hello\nworld\n"}, true},
+		{"world\nhello\n", &Example{Output: "hello\nworld"}, false},
+		{"world\nhello\n", &Example{Output: "hello\nworld", Unordered: true}, true},
+		{"hello\n", &Example{Output: "hello\nworld", Unordered: true}, false},
+	}
+	for _, test := range tests {
+		if got := outputMatches(test.got, test.example); got != test.want {
+			t.Errorf("outputMatches(%q, %q): got %v, want %v", test.got, test.example.Output, got, test.want)
+		}
+	}
+}
+
+func TestGetExampleResult(t *testing.T) {
+	first := getExampleResult("github.com/gowalker/db#first")
+	if r := getExampleResult("github.com/gowalker/db#first"); r != first {
+		t.Fatal("cached result is not reused")
+	}
+	for i := 0; i < MaxExampleResults; i++ {
+		getExampleResult(fmt.Sprintf("github.com/gowalker/db#%d", i))
+	}
+
+	exampleResults.Lock()
+	n, evicted := len(exampleResults.m), exampleResults.m[first.key] == nil
+	exampleResults.Unlock()
+	if n != MaxExampleResults || !evicted {
+		t.Errorf("got %d results (first evicted %v), want %d results with first evicted", n, evicted, MaxExampleResults)
+	}
+
+	removeExampleResult(first)
+	if r := getExampleResult(first.key); r == first {
+		t.Error("evicted result is reused")
+	}
+}
@@ -0,0 +1,392 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "gopkg.in/clog.v1"
+	"gopkg.in/macaron.v1"
+
+	"github.com/unknwon/gowalker/internal/db"
+)
+
+// Statuses of pre-warm jobs.
+const (
+	PrewarmQueued  = "queued"
+	PrewarmRunning = "running"
+	PrewarmDone    = "done"
+)
+
+// MaxPrewarmJobs is the maximum number of pre-warm jobs waiting in queue.
+const MaxPrewarmJobs = 100
+
+// prewarmJobTTL is how long progress of finished jobs is kept.
+const prewarmJobTTL = 24 * time.Hour
+
+var (
+	ErrPrewarmDisabled    = errors.New("pre-warming is disabled")
+	ErrPrewarmNoModule    = errors.New("no module is found in go.mod or go.sum")
+	ErrPrewarmQueueFull   = errors.New("too many pre-warm jobs in queue")
+	ErrPrewarmClientLimit = errors.New("too many unfinished pre-warm jobs of the client")
+	ErrPrewarmJobNotFound = errors.New("pre-warm job not found")
+)
+
+// isGoSum returns true if every line of the file is a checksum of module,
+// e.g. "github.com/unknwon/com v1.0.1 h1:3d1LTxD+Lnf3soQiD4Cp/0BRB+Rsa/+RTvz8GMMzIXI=".
+func isGoSum(data []byte) bool {
+	hasLine := false
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		} else if len(fields) != 3 || !strings.HasPrefix(fields[2], "h1:") {
+			return false
+		}
+		hasLine = true
+	}
+	return hasLine
+}
+
+// parseGoSum returns modules that have their source code checksums in go.sum,
+// the highest version is used when there are more than one. Modules that only
+// have checksums of their go.mod files are not built, thus left out.
+func parseGoSum(data []byte) []ModVersion {
+	versions := make(map[string]string)
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) != 3 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		} else if _, _, ok := splitSemver(fields[1]); !ok {
+			continue
+		}
+
+		path, version := fields[0], fields[1]
+		if cur, ok := versions[path]; !ok || compareSemver(version, cur) > 0 {
+			versions[path] = version
+		}
+	}
+
+	mods := make([]ModVersion, 0, len(versions))
+	for path, version := range versions {
+		mods = append(mods, ModVersion{path, version})
+	}
+	sort.Slice(mods, func(i, j int) bool {
+		return mods[i].Path < mods[j].Path
+	})
+	return mods
+}
+
+// parseModuleList returns modules listed in go.mod or go.sum, the format is
+// detected from the content. Replacements in go.mod are applied, and modules
+// replaced by local directories are left out.
+func parseModuleList(data []byte) ([]ModVersion, error) {
+	var mods []ModVersion
+	if isGoSum(data) {
+		mods = parseGoSum(data)
+	} else {
+		m, err := parseGoMod(data)
+		if err != nil {
+			return nil, err
+		}
+		seen := make(map[ModVersion]bool)
+		for _, r := range m.Requires {
+			mv, ok := m.Requirement(r.Path)
+			if !ok || seen[mv] {
+				continue
+			}
+			seen[mv] = true
+			mods = append(mods, mv)
+		}
+	}
+	if len(mods) == 0 {
+		return nil, ErrPrewarmNoModule
+	}
+	return mods, nil
+}
+
+// PrewarmModule is the progress of crawling packages of a module.
+type PrewarmModule struct {
+	Path     string `json:"path"`
+	Version  string `json:"version"`
+	Packages int    `json:"packages"` // Number of packages that are documented.
+	Cached   int    `json:"cached"`   // Number of packages that were already documented.
+	Failed   int    `json:"failed"`
+	Skipped  int    `json:"skipped"`         // Number of found packages left out by the limit.
+	Error    string `json:"error,omitempty"` // Error of the first failed package.
+	Done     bool   `json:"done"`
+}
+
+// PrewarmJob is a job that crawls all packages of modules listed in go.mod
+// or go.sum, so their documentation is ready before anyone visits.
+type PrewarmJob struct {
+	ID       string           `json:"id"`
+	Status   string           `json:"status"`
+	Created  int64            `json:"created"`
+	Finished int64            `json:"finished,omitempty"`
+	Modules  []*PrewarmModule `json:"modules"`
+
+	NumModules     int `json:"num_modules"`
+	NumDoneModules int `json:"num_done_modules"`
+	NumPackages    int `json:"num_packages"`
+	NumFailed      int `json:"num_failed"`
+	NumSkipped     int `json:"num_skipped"`
+
+	client      string
+	maxPackages int
+}
+
+// Jobs are kept in memory, progress is lost when the server restarts.
+var (
+	prewarmLock  sync.RWMutex
+	prewarmJobs  = make(map[string]*PrewarmJob)
+	prewarmQueue = make(chan *PrewarmJob, MaxPrewarmJobs)
+	prewarmOnce  sync.Once
+
+	// prewarmRender renders documentation of crawled packages, it must not
+	// be bound to any request because jobs outlive requests that queue them.
+	prewarmRender macaron.Render
+)
+
+// copy returns a copy of the job with numbers of progress summarized, it must
+// be called with prewarmLock held.
+func (j *PrewarmJob) copy() *PrewarmJob {
+	c := *j
+	c.client = ""
+	c.Modules = make([]*PrewarmModule, len(j.Modules))
+	c.NumModules = len(j.Modules)
+	for i := range j.Modules {
+		m := *j.Modules[i]
+		c.Modules[i] = &m
+		if m.Done {
+			c.NumDoneModules++
+		}
+		c.NumPackages += m.Packages
+		c.NumFailed += m.Failed
+		c.NumSkipped += m.Skipped
+	}
+	return &c
+}
+
+// hasAPIVersion returns true if the package has API snapshot of given version,
+// which is either tagged by the version or taken at the commit of pseudo-version.
+func hasAPIVersion(importPath, version string) bool {
+	versions, err := APIVersions(importPath)
+	if err != nil {
+		log.Error(2, "APIVersions %q: %v", importPath, err)
+		return false
+	}
+
+	version = strings.TrimSuffix(version, "+incompatible")
+	for _, v := range versions {
+		if v.Tag == version ||
+			(isPseudoVersion(version) && strings.HasPrefix(v.Etag, version[len(version)-12:])) {
+			return true
+		}
+	}
+	return false
+}
+
+// prewarmPackage makes sure the package is documented and returns its information.
+// Packages that are documented at the version, or were crawled recently, are not
+// crawled again. Otherwise the latest version is crawled since only one version
+// of documentation is kept for each package.
+var prewarmPackage = func(importPath, version string) (*db.PkgInfo, bool, error) {
+	pinfo, err := db.GetPkgInfo(importPath)
+	if err == nil && (!pinfo.CanRefresh() || hasAPIVersion(importPath, version)) {
+		return pinfo, true, nil
+	}
+
+	pinfo, err = CheckPackage(importPath, prewarmRender, RequestTypeRefresh)
+	return pinfo, false, err
+}
+
+// prewarmModule crawls the package at module root and then packages in its
+// subdirectories, except the ones belong to nested modules. Packages found
+// after the limit of the job is reached are skipped.
+func (j *PrewarmJob) prewarmModule(m *PrewarmModule) {
+	importPaths := []string{m.Path}
+	for numCrawled := 0; len(importPaths) > 0; numCrawled++ {
+		if j.maxPackages > 0 && numCrawled >= j.maxPackages {
+			log.Trace("Prewarm[%s]: skipped %d packages of %q", j.ID, len(importPaths), m.Path)
+			prewarmLock.Lock()
+			m.Skipped = len(importPaths)
+			prewarmLock.Unlock()
+			break
+		}
+
+		importPath := importPaths[0]
+		importPaths = importPaths[1:]
+
+		pinfo, cached, err := prewarmPackage(importPath, m.Version)
+		prewarmLock.Lock()
+		switch {
+		case err != nil:
+			m.Failed++
+			if len(m.Error) == 0 {
+				m.Error = fmt.Sprintf("%s: %v", importPath, err)
+			}
+		case cached:
+			m.Cached++
+			fallthrough
+		default:
+			m.Packages++
+		}
+		prewarmLock.Unlock()
+		if err != nil {
+			log.Trace("Prewarm[%s]: failed to crawl %q: %v", j.ID, importPath, err)
+			continue
+		}
+
+		nested := make(map[string]bool)
+		for _, dir := range strings.Split(pinfo.NestedModules, "|") {
+			nested[dir] = true
+		}
+		for _, dir := range strings.Split(pinfo.Subdirs, "|") {
+			if len(dir) > 0 && !nested[dir] {
+				importPaths = append(importPaths, importPath+"/"+dir)
+			}
+		}
+	}
+
+	prewarmLock.Lock()
+	m.Done = true
+	prewarmLock.Unlock()
+}
+
+func (j *PrewarmJob) run() {
+	log.Trace("Prewarm[%s]: started with %d modules", j.ID, len(j.Modules))
+
+	prewarmLock.Lock()
+	j.Status = PrewarmRunning
+	prewarmLock.Unlock()
+
+	for _, m := range j.Modules {
+		j.prewarmModule(m)
+	}
+
+	prewarmLock.Lock()
+	j.Status = PrewarmDone
+	j.Finished = time.Now().Unix()
+	prewarmLock.Unlock()
+
+	log.Trace("Prewarm[%s]: finished", j.ID)
+}
+
+// runPrewarmJobs runs queued jobs one by one, so crawling does not exceed
+// rate limits of code hosting services.
+func runPrewarmJobs() {
+	for j := range prewarmQueue {
+		j.run()
+	}
+}
+
+// StartPrewarm starts running queued jobs, documentation of crawled packages
+// is rendered by given renderer.
+func StartPrewarm(render macaron.Render) {
+	prewarmOnce.Do(func() {
+		prewarmRender = render
+		go runPrewarmJobs()
+	})
+}
+
+// PrewarmOptions contains limits of a pre-warm job, limits that are not
+// positive are not applied.
+type PrewarmOptions struct {
+	Client        string // Identifier of the client that queues the job, e.g. IP address.
+	MaxModules    int
+	MaxPackages   int // Maximum number of packages crawled for each module.
+	MaxClientJobs int // Maximum number of unfinished jobs of the client.
+}
+
+// Prewarm queues a job to crawl all packages of modules listed in given go.mod
+// or go.sum, and returns a copy of the job whose progress can be polled by
+// GetPrewarmJob. Jobs are only run after StartPrewarm is called.
+func Prewarm(data []byte, opts PrewarmOptions) (*PrewarmJob, error) {
+	mods, err := parseModuleList(data)
+	if err != nil {
+		return nil, err
+	} else if opts.MaxModules > 0 && len(mods) > opts.MaxModules {
+		return nil, fmt.Errorf("too many modules: %d > %d", len(mods), opts.MaxModules)
+	}
+
+	id := make([]byte, 8)
+	if _, err = rand.Read(id); err != nil {
+		return nil, fmt.Errorf("generate job ID: %v", err)
+	}
+	j := &PrewarmJob{
+		ID:          hex.EncodeToString(id),
+		Status:      PrewarmQueued,
+		Created:     time.Now().Unix(),
+		Modules:     make([]*PrewarmModule, len(mods)),
+		client:      opts.Client,
+		maxPackages: opts.MaxPackages,
+	}
+	for i, mv := range mods {
+		j.Modules[i] = &PrewarmModule{
+			Path:    mv.Path,
+			Version: mv.Version,
+		}
+	}
+
+	prewarmLock.Lock()
+	defer prewarmLock.Unlock()
+
+	outdated := time.Now().Add(-prewarmJobTTL).Unix()
+	numClientJobs := 0
+	for id, cj := range prewarmJobs {
+		if cj.Status == PrewarmDone {
+			if cj.Finished < outdated {
+				delete(prewarmJobs, id)
+			}
+		} else if cj.client == opts.Client {
+			numClientJobs++
+		}
+	}
+	if opts.MaxClientJobs > 0 && numClientJobs >= opts.MaxClientJobs {
+		return nil, ErrPrewarmClientLimit
+	}
+
+	select {
+	case prewarmQueue <- j:
+	default:
+		return nil, ErrPrewarmQueueFull
+	}
+	prewarmJobs[j.ID] = j
+	return j.copy(), nil
+}
+
+// GetPrewarmJob returns a copy of the job with its current progress.
+func GetPrewarmJob(id string) (*PrewarmJob, error) {
+	prewarmLock.RLock()
+	defer prewarmLock.RUnlock()
+
+	j, ok := prewarmJobs[id]
+	if !ok {
+		return nil, ErrPrewarmJobNotFound
+	}
+	return j.copy(), nil
+}
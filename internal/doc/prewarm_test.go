@@ -0,0 +1,242 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/unknwon/gowalker/internal/db"
+	"github.com/unknwon/gowalker/internal/setting"
+)
+
+func TestParseModuleList(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		expMods []ModVersion
+		expErr  string
+	}{
+		{
+			name: "go.mod",
+			data: `module github.com/gowalker/service
+
+go 1.21
+
+require (
+	github.com/unknwon/com v1.0.1
+	github.com/gowalker/fork v0.1.0
+	github.com/gowalker/local v0.2.0
+	golang.org/x/text v0.3.2 // indirect
+)
+
+replace github.com/gowalker/fork => github.com/gowalker/upstream v0.1.1
+
+replace github.com/gowalker/local => ../local
+`,
+			expMods: []ModVersion{
+				{"github.com/unknwon/com", "v1.0.1"},
+				{"github.com/gowalker/upstream", "v0.1.1"},
+				{"golang.org/x/text", "v0.3.2"},
+			},
+		},
+		{
+			name: "go.sum",
+			data: `github.com/unknwon/com v0.0.0-20190804042917-757f69c95f3e h1:GSGeB9EAKY2spCABz6xOX5DbxZEXolK+nBSvmsQwRjM=
+github.com/unknwon/com v0.0.0-20190804042917-757f69c95f3e/go.mod h1:tOOxU81rwgoCLoOVVPHb6T/wt8HZygqH5id+GNnlCXM=
+github.com/unknwon/com v1.0.1 h1:3d1LTxD+Lnf3soQiD4Cp/0BRB+Rsa/+RTvz8GMMzIXI=
+github.com/unknwon/com v1.0.1/go.mod h1:tOOxU81rwgoCLoOVVPHb6T/wt8HZygqH5id+GNnlCXM=
+golang.org/x/net v0.0.0-20190311183353-d8887717615a/go.mod h1:t9HGtf8HONx5eT2rtn7q6eTqICYqUVnKs3thJo3Qplg=
+gopkg.in/ini.v1 v1.46.0 h1:VeDZbLYGaupuvIrsYCEOe/L/2Pcs5n7hdO1ItjJ3NRk=
+`,
+			expMods: []ModVersion{
+				{"github.com/unknwon/com", "v1.0.1"},
+				{"gopkg.in/ini.v1", "v1.46.0"},
+			},
+		},
+		{
+			name:   "no requirement",
+			data:   "module github.com/gowalker/service\n",
+			expErr: ErrPrewarmNoModule.Error(),
+		},
+		{
+			name:   "neither",
+			data:   "hello world\n",
+			expErr: "go.mod: no module directive",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mods, err := parseModuleList([]byte(test.data))
+			if len(test.expErr) > 0 {
+				if err == nil || err.Error() != test.expErr {
+					t.Fatalf("Expected error %q but got %v", test.expErr, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("parseModuleList: %v", err)
+			}
+			if !reflect.DeepEqual(mods, test.expMods) {
+				t.Errorf("Expected %v but got %v", test.expMods, mods)
+			}
+		})
+	}
+}
+
+func TestPrewarm(t *testing.T) {
+	pinfos := map[string]*db.PkgInfo{
+		"github.com/gowalker/multi":            {Subdirs: "sub|util", NestedModules: "sub"},
+		"github.com/gowalker/multi/util":       {Subdirs: "deep"},
+		"github.com/gowalker/multi/util/deep":  {},
+		"github.com/gowalker/hello":            {},
+		"github.com/gowalker/multi/sub/ignore": {},
+	}
+	var crawled []string
+	defer func(f func(string, string) (*db.PkgInfo, bool, error)) {
+		prewarmPackage = f
+	}(prewarmPackage)
+	prewarmPackage = func(importPath, version string) (*db.PkgInfo, bool, error) {
+		crawled = append(crawled, importPath+"@"+version)
+		pinfo, ok := pinfos[importPath]
+		if !ok {
+			return nil, false, errors.New("resource not found")
+		}
+		return pinfo, importPath == "github.com/gowalker/hello", nil
+	}
+
+	const goMod = `module github.com/gowalker/service
+
+require (
+	github.com/gowalker/multi v1.2.0
+	github.com/gowalker/hello v0.1.0
+	github.com/gowalker/missing v0.0.1
+)
+`
+	if _, err := Prewarm([]byte(goMod), PrewarmOptions{MaxModules: 2}); err == nil {
+		t.Error("Number of modules is not limited")
+	}
+
+	opts := PrewarmOptions{Client: "127.0.0.1", MaxClientJobs: 1}
+	job, err := Prewarm([]byte(goMod), opts)
+	if err != nil {
+		t.Fatalf("Prewarm: %v", err)
+	}
+	if len(job.ID) == 0 || job.NumModules != 3 {
+		t.Fatalf("Unexpected job: %+v", job)
+	}
+	// Jobs are not run until started, so the first one is still unfinished.
+	if _, err = Prewarm([]byte(goMod), opts); err != ErrPrewarmClientLimit {
+		t.Errorf("Expected ErrPrewarmClientLimit but got %v", err)
+	}
+	StartPrewarm(nil)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for job.Status != PrewarmDone {
+		if time.Now().After(deadline) {
+			t.Fatal("Job is not done in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+		if job, err = GetPrewarmJob(job.ID); err != nil {
+			t.Fatalf("GetPrewarmJob: %v", err)
+		}
+	}
+
+	expCrawled := []string{
+		"github.com/gowalker/multi@v1.2.0",
+		"github.com/gowalker/multi/util@v1.2.0",
+		"github.com/gowalker/multi/util/deep@v1.2.0",
+		"github.com/gowalker/hello@v0.1.0",
+		"github.com/gowalker/missing@v0.0.1",
+	}
+	if !reflect.DeepEqual(crawled, expCrawled) {
+		t.Errorf("Expected crawled %v but got %v", expCrawled, crawled)
+	}
+	if job.NumDoneModules != 3 || job.NumPackages != 4 || job.NumFailed != 1 {
+		t.Errorf("Unexpected progress: %+v", job)
+	}
+	if m := job.Modules[1]; m.Packages != 1 || m.Cached != 1 {
+		t.Errorf("Unexpected progress of %q: %+v", m.Path, m)
+	}
+	if m := job.Modules[2]; m.Error != "github.com/gowalker/missing: resource not found" {
+		t.Errorf("Unexpected error of %q: %q", m.Path, m.Error)
+	}
+
+	if _, err = GetPrewarmJob("nonexistent"); err != ErrPrewarmJobNotFound {
+		t.Errorf("Expected ErrPrewarmJobNotFound but got %v", err)
+	}
+
+	// Packages beyond the limit are skipped.
+	crawled = nil
+	job, err = Prewarm([]byte("module github.com/gowalker/service\n\nrequire github.com/gowalker/multi v1.2.0\n"), PrewarmOptions{MaxPackages: 2})
+	if err != nil {
+		t.Fatalf("Prewarm: %v", err)
+	}
+	for job.Status != PrewarmDone {
+		if time.Now().After(deadline) {
+			t.Fatal("Job is not done in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+		if job, err = GetPrewarmJob(job.ID); err != nil {
+			t.Fatalf("GetPrewarmJob: %v", err)
+		}
+	}
+	if len(crawled) != 2 || job.NumPackages != 2 || job.NumSkipped != 1 || job.Modules[0].Skipped != 1 {
+		t.Errorf("Unexpected progress with crawled %v: %+v", crawled, job)
+	}
+}
+
+func TestHasAPIVersion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer func(old string) { setting.DocsGobPath = old }(setting.DocsGobPath)
+	setting.DocsGobPath = dir + "/"
+
+	importPath := "github.com/gowalker/hello"
+	for _, tag := range []string{"", "v2.1.0"} {
+		pdoc := &Package{
+			PkgInfo: &db.PkgInfo{ImportPath: importPath, Etag: "757f69c95f3e" + tag},
+			PkgDecl: &PkgDecl{Tag: tag},
+		}
+		if err = saveAPISnapshot(pdoc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"v2.1.0", true},
+		{"v2.1.0+incompatible", true},
+		{"v2.0.0", false},
+		{"v0.0.0-20190804042917-757f69c95f3e", true},
+		{"v0.0.0-20190804042917-1234567890ab", false},
+	}
+	for _, test := range tests {
+		if got := hasAPIVersion(importPath, test.version); got != test.want {
+			t.Errorf("hasAPIVersion(%q) = %v, want %v", test.version, got, test.want)
+		}
+	}
+	if hasAPIVersion("github.com/gowalker/nonexistent", "v2.1.0") {
+		t.Error("Package without API snapshots has version")
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// maxEmbeddingDepth is the maximum depth of embedded fields to look for promoted fields.
+const maxEmbeddingDepth = 5
+
+// PromotedMember is a field or a method promoted from an embedded type.
+type PromotedMember struct {
+	Name string
+	Decl string
+	Path string // Link of the originating declaration.
+}
+
+// PromotedGroup is a group of fields and methods promoted from the same type.
+type PromotedGroup struct {
+	From    string // Name of the type that declares the members, qualified if it is from another package.
+	Path    string // Link of documentation of the type.
+	Fields  []*PromotedMember
+	Methods []*PromotedMember
+}
+
+// localImporter fails to import any package except "unsafe", it is used to
+// type-check a package alone.
+type localImporter struct{}
+
+func (localImporter) Import(path string) (*types.Package, error) {
+	if path == "unsafe" {
+		return types.Unsafe, nil
+	}
+	return nil, fmt.Errorf("not imported: %s", path)
+}
+
+// checkLocal type-checks files of the package without importing other packages,
+// which is enough to resolve types embedded from the same package.
+func checkLocal(importPath string, fset *token.FileSet, files map[string]*ast.File) *types.Package {
+	conf := types.Config{
+		Importer:         localImporter{},
+		FakeImportC:      true,
+		IgnoreFuncBodies: true,
+		Error:            func(error) {},
+	}
+	pkg, _ := conf.Check(importPath, fset, sortedFiles(files), nil)
+	return pkg
+}
+
+// promotedFrom returns name and documentation link of the named type relative
+// to the package, the link is empty for unexported types.
+func promotedFrom(pkg *types.Package, named *types.Named) (name, path string) {
+	obj := named.Origin().Obj()
+	if !obj.Exported() {
+		return obj.Name(), ""
+	} else if obj.Pkg() == nil || obj.Pkg() == pkg {
+		return obj.Name(), "#" + obj.Name()
+	}
+	return obj.Pkg().Name() + "." + obj.Name(), "/" + obj.Pkg().Path() + "#" + obj.Name()
+}
+
+// namedOf returns the named type of t, pointers are dereferenced.
+func namedOf(t types.Type) *types.Named {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, _ := t.(*types.Named)
+	return named
+}
+
+// promotedFieldNames returns names of exported fields of embedded structs.
+func promotedFieldNames(st *types.Struct, depth int, seen map[*types.Named]bool, names map[string]bool) {
+	if depth > maxEmbeddingDepth {
+		return
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if depth > 0 && f.Exported() {
+			names[f.Name()] = true
+		}
+		if !f.Embedded() {
+			continue
+		}
+		named := namedOf(f.Type())
+		if named == nil || seen[named] {
+			continue
+		}
+		seen[named] = true
+		if est, ok := named.Underlying().(*types.Struct); ok {
+			promotedFieldNames(est, depth+1, seen, names)
+		}
+	}
+}
+
+// promotions returns fields and methods of the named type that are promoted
+// from its embedded types, grouped by the types that declare them.
+func promotions(pkg *types.Package, named *types.Named) []*PromotedGroup {
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	qualifier := types.RelativeTo(pkg)
+	groups := make(map[string]*PromotedGroup)
+	group := func(from *types.Named) *PromotedGroup {
+		name, path := promotedFrom(pkg, from)
+		g := groups[name]
+		if g == nil {
+			g = &PromotedGroup{From: name, Path: path}
+			groups[name] = g
+		}
+		return g
+	}
+
+	names := make(map[string]bool)
+	promotedFieldNames(st, 0, map[*types.Named]bool{named: true}, names)
+	for name := range names {
+		// Shadowed and ambiguous names are not promoted.
+		obj, index, _ := types.LookupFieldOrMethod(named, true, pkg, name)
+		field, ok := obj.(*types.Var)
+		if !ok || len(index) < 2 {
+			continue
+		}
+		owner := namedOf(embeddedOwner(named, index))
+		if owner == nil {
+			continue
+		}
+		g := group(owner)
+		m := &PromotedMember{
+			Name: name,
+			Decl: name + " " + types.TypeString(field.Type(), qualifier),
+		}
+		if len(g.Path) > 0 {
+			m.Path = g.Path + "." + name
+		}
+		g.Fields = append(g.Fields, m)
+	}
+
+	mset := types.NewMethodSet(types.NewPointer(named))
+	for i := 0; i < mset.Len(); i++ {
+		sel := mset.At(i)
+		fn := sel.Obj().(*types.Func)
+		if len(sel.Index()) < 2 || !fn.Exported() {
+			continue
+		}
+		sig := fn.Type().(*types.Signature)
+		if sig.Recv() == nil {
+			continue
+		}
+		from := namedOf(sig.Recv().Type())
+		// Methods promoted from unexported types are already listed as the
+		// type's own methods.
+		if from == nil || !from.Obj().Exported() {
+			continue
+		}
+
+		g := group(from)
+		sep := "_"
+		if types.IsInterface(from) {
+			sep = "."
+		}
+		g.Methods = append(g.Methods, &PromotedMember{
+			Name: fn.Name(),
+			Decl: "func " + fn.Name() + strings.TrimPrefix(types.TypeString(sig, qualifier), "func"),
+			Path: g.Path + sep + fn.Name(),
+		})
+	}
+
+	list := make([]*PromotedGroup, 0, len(groups))
+	for _, g := range groups {
+		sort.Slice(g.Fields, func(i, j int) bool { return g.Fields[i].Name < g.Fields[j].Name })
+		sort.Slice(g.Methods, func(i, j int) bool { return g.Methods[i].Name < g.Methods[j].Name })
+		list = append(list, g)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].From < list[j].From })
+	return list
+}
+
+// promotions fills fields and methods promoted from embedded types of types
+// of the package.
+func (w *Walker) promotions(pkg *types.Package) {
+	for _, t := range w.Pdoc.Types {
+		obj, ok := pkg.Scope().Lookup(t.Name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		if named, ok := obj.Type().(*types.Named); ok {
+			t.Promoted = promotions(pkg, named)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/unknwon/gowalker/internal/db"
+	"github.com/unknwon/gowalker/internal/setting"
+)
+
+func TestWalker_Promotions(t *testing.T) {
+	pdoc := buildTestPackage(t, "github.com/gowalker/rw", &Source{SrcName: "rw.go", SrcData: []byte(`package rw
+
+import "io"
+
+type Reader struct {
+	Size int
+	Buf  []byte
+}
+
+func (r *Reader) Read(p []byte) (int, error) { return 0, nil }
+func (r Reader) Len() int                     { return 0 }
+
+type Writer struct {
+	Size int
+}
+
+func (w *Writer) Write(p []byte) (int, error) { return 0, nil }
+func (w *Writer) Len() int                     { return 0 }
+
+type Closer interface {
+	Close() error
+}
+
+type base struct {
+	Name string
+}
+
+func (base) Reset() {}
+
+type ReadWriter struct {
+	*Reader
+	*Writer
+	Closer
+	base
+	io.Seeker
+}
+
+func (rw *ReadWriter) Flush() error { return nil }
+`)})
+
+	var rw *Type
+	for _, tp := range pdoc.Types {
+		if tp.Name == "ReadWriter" {
+			rw = tp
+		}
+	}
+	if rw == nil {
+		t.Fatal("type ReadWriter not found")
+	}
+
+	// Ambiguous "Size" and "Len" are not promoted, methods of unexported "base"
+	// are listed as its own methods, and "io.Seeker" cannot be resolved without
+	// type checking dependencies.
+	want := []*PromotedGroup{
+		{
+			From: "Closer",
+			Path: "#Closer",
+			Methods: []*PromotedMember{
+				{Name: "Close", Decl: "func Close() error", Path: "#Closer.Close"},
+			},
+		},
+		{
+			From: "Reader",
+			Path: "#Reader",
+			Fields: []*PromotedMember{
+				{Name: "Buf", Decl: "Buf []byte", Path: "#Reader.Buf"},
+			},
+			Methods: []*PromotedMember{
+				{Name: "Read", Decl: "func Read(p []byte) (int, error)", Path: "#Reader_Read"},
+			},
+		},
+		{
+			From: "Writer",
+			Path: "#Writer",
+			Methods: []*PromotedMember{
+				{Name: "Write", Decl: "func Write(p []byte) (int, error)", Path: "#Writer_Write"},
+			},
+		},
+		{
+			From: "base",
+			Fields: []*PromotedMember{
+				{Name: "Name", Decl: "Name string"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(rw.Promoted, want) {
+		for _, g := range rw.Promoted {
+			t.Logf("%+v", *g)
+			for _, m := range append(g.Fields, g.Methods...) {
+				t.Logf("  %+v", *m)
+			}
+		}
+		t.Fatal("unexpected promoted fields and methods")
+	}
+}
+
+func TestWalker_PromotionsFromDependency(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer func(old string) { setting.DocsGobPath = old }(setting.DocsGobPath)
+	setting.DocsGobPath = dir + "/"
+	defer func(old bool) { setting.TypeCheck = old }(setting.TypeCheck)
+	setting.TypeCheck = true
+
+	err = saveGob(&Package{
+		PkgInfo: &db.PkgInfo{ImportPath: "github.com/gowalker/buf"},
+		PkgDecl: &PkgDecl{Files: []*Source{{SrcName: "buf.go", SrcData: []byte(`package buf
+
+type Buffer struct {
+	Cap int
+}
+
+func (b *Buffer) Bytes() []byte { return nil }
+`)}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pdoc := buildTestPackage(t, "github.com/gowalker/app", &Source{SrcName: "app.go", SrcData: []byte(`package app
+
+import "github.com/gowalker/buf"
+
+type Page struct {
+	buf.Buffer
+}
+`)})
+
+	want := []*PromotedGroup{
+		{
+			From: "buf.Buffer",
+			Path: "/github.com/gowalker/buf#Buffer",
+			Fields: []*PromotedMember{
+				{Name: "Cap", Decl: "Cap int", Path: "/github.com/gowalker/buf#Buffer.Cap"},
+			},
+			Methods: []*PromotedMember{
+				{Name: "Bytes", Decl: "func Bytes() []byte", Path: "/github.com/gowalker/buf#Buffer_Bytes"},
+			},
+		},
+	}
+	if got := pdoc.Types[0].Promoted; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+var ErrSourceNotFound = errors.New("source file not found")
+
+// SourceURL returns URL of the file of the package in source viewer.
+func SourceURL(importPath, name string) string {
+	return "/" + importPath + "/-/src/" + name
+}
+
+// SourceFile is a highlighted source file of package.
+type SourceFile struct {
+	Name      string
+	BrowseUrl string // URL of the file in external code browser.
+	Lines     []int  // Line numbers.
+	Code      string // Highlighted code in HTML.
+}
+
+// sourceLink is a link of an identifier or a qualified identifier in source file.
+type sourceLink struct {
+	end   int // Offset of the end of linked text.
+	class string
+	href  string
+}
+
+// sourceLinker resolves links of identifiers in a source file.
+type sourceLinker struct {
+	fset       *token.FileSet
+	importPath string
+	name       string     // Name of the source file.
+	scope      *ast.Scope // Package scope.
+	isDoc      bool       // Indicates if declarations of the file are in documentation.
+	links      map[int]*sourceLink
+}
+
+func (l *sourceLinker) add(pos, end token.Pos, class, href string) {
+	l.links[l.fset.Position(pos).Offset] = &sourceLink{
+		end:   l.fset.Position(end).Offset,
+		class: class,
+		href:  href,
+	}
+}
+
+// ident links identifier to its documentation if it is an exported declaration
+// of package, otherwise to its definition.
+func (l *sourceLinker) ident(id *ast.Ident) {
+	obj := id.Obj
+	if obj == nil || obj.Kind == ast.Pkg || obj.Kind == ast.Bad {
+		return
+	}
+
+	isPkgLevel := l.scope.Lookup(id.Name) == obj
+	if l.isDoc && isPkgLevel && ast.IsExported(id.Name) {
+		l.add(id.Pos(), id.End(), "int", "/"+l.importPath+"#"+id.Name)
+		return
+	}
+
+	pos := obj.Pos()
+	if !pos.IsValid() || pos == id.Pos() {
+		return
+	}
+	position := l.fset.Position(pos)
+	href := fmt.Sprintf("#L%d", position.Line)
+	if position.Filename != l.name {
+		href = SourceURL(l.importPath, position.Filename) + href
+	}
+	l.add(id.Pos(), id.End(), "int", href)
+}
+
+func (l *sourceLinker) Visit(n ast.Node) ast.Visitor {
+	switch n := n.(type) {
+	case *ast.FuncDecl:
+		// Methods are not declared in any scope.
+		if n.Recv != nil && l.isDoc && ast.IsExported(n.Name.Name) {
+			if recv, _ := recvTypeParams(n.Recv); ast.IsExported(recv) {
+				l.add(n.Name.Pos(), n.Name.End(), "int", "/"+l.importPath+"#"+recv+"_"+n.Name.Name)
+			}
+		}
+	case *ast.SelectorExpr:
+		x, _ := n.X.(*ast.Ident)
+		if x == nil || x.Obj == nil || x.Obj.Kind != ast.Pkg {
+			break
+		}
+		if spec, _ := x.Obj.Decl.(*ast.ImportSpec); spec != nil {
+			if path, err := strconv.Unquote(spec.Path.Value); err == nil && path != "C" {
+				l.add(x.Pos(), n.Sel.End(), "ext", "/"+path+"#"+n.Sel.Name)
+			}
+		}
+		return nil
+	case *ast.ImportSpec:
+		if path, err := strconv.Unquote(n.Path.Value); err == nil && path != "C" {
+			l.add(n.Path.Pos(), n.Path.End(), "ext", "/"+path)
+		}
+		return nil
+	case *ast.Ident:
+		l.ident(n)
+	}
+	return l
+}
+
+// formatSource renders Go source code as highlighted HTML with given links.
+func formatSource(src string, links map[int]*sourceLink) string {
+	w := newCodeWriter(nil)
+	last := 0
+	for _, t := range scanCode(src) {
+		if t.start < last {
+			// Covered by previous link.
+			continue
+		}
+		w.space(src[last:t.start])
+		last = t.end
+		s := src[t.start:t.end]
+
+		switch l := links[t.start]; {
+		case l != nil && l.end >= t.end && l.end <= len(src):
+			w.anchor(l.class, l.href, "", src[t.start:l.end])
+			last = l.end
+		case t.tok == token.IDENT:
+			if !w.predeclared(s) {
+				w.text(s)
+			}
+		default:
+			w.token(t, s)
+		}
+	}
+	w.space(src[last:])
+	return w.String()
+}
+
+// renderSource returns highlighted source file of the package by its name.
+func renderSource(pdoc *Package, name string) (*SourceFile, error) {
+	var srcs []*Source
+	srcs = append(srcs, pdoc.Files...)
+	srcs = append(srcs, pdoc.TestFiles...)
+	srcs = append(srcs, pdoc.IgnoredFiles...)
+
+	var file *Source
+	for _, src := range srcs {
+		if src.SrcName == name {
+			file = src
+			break
+		}
+	}
+	if file == nil || !strings.HasSuffix(name, ".go") {
+		return nil, ErrSourceNotFound
+	}
+
+	// Identifiers are resolved with all files of the same package.
+	fset := token.NewFileSet()
+	files := make(map[string]*ast.File)
+	texts := make(map[string]string)
+	for _, src := range srcs {
+		texts[src.SrcName] = strings.Replace(string(src.SrcData), "\r", "", -1)
+		// Files with syntax errors are rendered without links.
+		if f, err := parser.ParseFile(fset, src.SrcName, texts[src.SrcName], parser.ParseComments); err == nil {
+			files[src.SrcName] = f
+		}
+	}
+
+	links := make(map[int]*sourceLink)
+	if f := files[name]; f != nil {
+		pkgFiles := make(map[string]*ast.File)
+		for fname, pf := range files {
+			if pf.Name.Name == f.Name.Name {
+				pkgFiles[fname] = pf
+			}
+		}
+		// Errors are expected for declarations in files of different platforms.
+		apkg, _ := ast.NewPackage(fset, pkgFiles, poorMansImporter, nil)
+		ast.Walk(&sourceLinker{
+			fset:       fset,
+			importPath: pdoc.ImportPath,
+			name:       name,
+			scope:      apkg.Scope,
+			isDoc:      f.Name.Name == pdoc.Name,
+			links:      links,
+		}, f)
+	}
+
+	text := texts[name]
+	numLines := strings.Count(text, "\n")
+	if !strings.HasSuffix(text, "\n") {
+		numLines++
+	}
+	lines := make([]int, numLines)
+	for i := range lines {
+		lines[i] = i + 1
+	}
+	return &SourceFile{
+		Name:      name,
+		BrowseUrl: file.BrowseUrl,
+		Lines:     lines,
+		Code:      formatSource(text, links),
+	}, nil
+}
+
+// RenderSource returns highlighted source file of the package from stored gob file.
+func RenderSource(importPath, name string) (*SourceFile, error) {
+	pdoc, err := loadGob(importPath)
+	if err != nil {
+		return nil, err
+	}
+	return renderSource(pdoc, name)
+}
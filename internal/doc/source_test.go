@@ -0,0 +1,91 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/unknwon/gowalker/internal/db"
+)
+
+func TestRenderSource(t *testing.T) {
+	pdoc := &Package{
+		PkgInfo: &db.PkgInfo{ImportPath: "github.com/gowalker/cache", Name: "cache"},
+		PkgDecl: &PkgDecl{
+			Files: []*Source{
+				{SrcName: "cache.go", SrcData: []byte(`package cache
+
+import "strings"
+
+// Cache stores values.
+type Cache struct{ m map[string]string }
+
+func (c *Cache) Get(key string) string {
+	return c.m[normalize(key)]
+}
+`)},
+				{SrcName: "util.go", SrcData: []byte("package cache\n\nimport \"strings\"\n\nfunc normalize(s string) string { return strings.ToLower(s) }\n")},
+			},
+			TestFiles: []*Source{
+				{SrcName: "cache_test.go", SrcData: []byte("package cache_test\n\nfunc TestGet() {}\n")},
+			},
+		},
+	}
+
+	file, err := renderSource(pdoc, "cache.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}; !reflect.DeepEqual(file.Lines, want) {
+		t.Errorf("Lines: got %v, want %v", file.Lines, want)
+	}
+	for _, s := range []string{
+		`<a class="ext" target="_blank" href="/strings">&#34;strings&#34;</a>`,
+		`<a class="int" target="_blank" href="/github.com/gowalker/cache#Cache">Cache</a> <span class="key">struct</span>`,
+		`(c *<a class="int" target="_blank" href="/github.com/gowalker/cache#Cache">Cache</a>)`,
+		`<a class="int" target="_blank" href="/github.com/gowalker/cache#Cache_Get">Get</a>(key string)`,
+		`<a class="int" href="#L8">c</a>.m[`,
+		`<a class="int" target="_blank" href="/github.com/gowalker/cache/-/src/util.go#L5">normalize</a>(<a class="int" href="#L8">key</a>)`,
+		`<span class="com">// Cache stores values.</span>`,
+	} {
+		if !strings.Contains(file.Code, s) {
+			t.Errorf("Code does not contain %q:\n%s", s, file.Code)
+		}
+	}
+
+	file, err = renderSource(pdoc, "util.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := `<a class="ext" target="_blank" href="/strings#ToLower">strings.ToLower</a>(<a class="int" href="#L5">s</a>)`; !strings.Contains(file.Code, s) {
+		t.Errorf("Code does not contain %q:\n%s", s, file.Code)
+	}
+
+	// Declarations of external test package are not in documentation.
+	file, err = renderSource(pdoc, "cache_test.go")
+	if err != nil {
+		t.Fatal(err)
+	} else if strings.Contains(file.Code, "<a") {
+		t.Errorf("Code should not contain links:\n%s", file.Code)
+	}
+
+	for _, name := range []string{"missing.go", "../cache.go", "README"} {
+		if _, err = renderSource(pdoc, name); err != ErrSourceNotFound {
+			t.Errorf("%q: got error %v, want %v", name, err, ErrSourceNotFound)
+		}
+	}
+}
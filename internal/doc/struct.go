@@ -18,6 +18,7 @@ import (
 	"go/ast"
 	"go/doc"
 	"go/token"
+	"go/types"
 	"os"
 	"time"
 
@@ -44,20 +45,32 @@ func (s *Source) SetData(p []byte)   { s.SrcData = p }
 
 // Example represents function or method examples.
 type Example struct {
-	Name string
-	Doc  string
-	Code string
-	//Play   string
-	Output string
-	IsUsed bool // Indicates if it's used by any kind object.
+	Name      string
+	Doc       string
+	Code      string
+	Play      string // Complete program of the example.
+	Output    string
+	Unordered bool // Indicates if lines of output can be in any order.
+	IsUsed    bool // Indicates if it's used by any kind object.
 }
 
 // Value represents constants and variable
 type Value struct {
-	Name          string // Value name.
+	Name          string   // Value name.
+	Names         []string // Names declared in the group.
 	Doc           string
-	Decl, FmtDecl string // Normal and formatted form of declaration.
-	URL           string // VCS URL.
+	Decl, FmtDecl string       // Normal and formatted form of declaration.
+	Annotations   []Annotation // Annotations of declaration.
+	URL           string       // VCS URL.
+	Platforms     []string     // Nil if it exists on all platforms.
+	Deprecated    string       // Deprecation note, empty if not deprecated.
+}
+
+// TypeParam is a type parameter of generic type or function.
+type TypeParam struct {
+	Name                      string
+	Anchor                    string
+	Constraint, FmtConstraint string
 }
 
 // Func represents functions
@@ -65,17 +78,32 @@ type Func struct {
 	Name, FullName string
 	Doc            string
 	Decl, FmtDecl  string
-	URL            string // VCS URL.
-	Code           string // Included field 'Decl', formatted.
-	Examples       []*Example
+	Annotations    []Annotation // Annotations of declaration.
+	URL            string       // VCS URL.
+	Code           string       // Included field 'Decl', formatted.
+	// Annotations of code resolved by type checking, nil if the package is not type-checked.
+	CodeAnnotations []Annotation
+	Platforms       []string // Nil if it exists on all platforms.
+	TypeParams      []*TypeParam
+	Examples        []*Example
+	Deprecated      string // Deprecation note, empty if not deprecated.
 }
 
 // Type represents structs and interfaces.
 type Type struct {
 	Name          string // Type name.
 	Doc           string
-	Decl, FmtDecl string // Normal and formatted form of declaration.
-	URL           string // VCS URL.
+	Decl, FmtDecl string       // Normal and formatted form of declaration.
+	Annotations   []Annotation // Annotations of declaration.
+	URL           string       // VCS URL.
+	Platforms     []string     // Nil if it exists on all platforms.
+	TypeParams    []*TypeParam
+	Deprecated    string // Deprecation note, empty if not deprecated.
+
+	Fields  []*Field // Exported fields of struct.
+	TagKeys []string // Keys of struct tags of fields.
+	// Exported methods and embedded interfaces of interface.
+	InterfaceMethods []*Field
 
 	Consts, Vars []*Value
 	Funcs        []*Func // Exported functions that return this type.
@@ -85,6 +113,29 @@ type Type struct {
 	IMethods []*Func // Internal methods.
 
 	Examples []*Example
+
+	// Resolved by type checking.
+	IsInterface   bool
+	Implements    []*Implementation // Interfaces implemented by the concrete type.
+	ImplementedBy []*Implementation // Types of the package implementing the interface.
+	Promoted      []*PromotedGroup  // Fields and methods promoted from embedded types.
+}
+
+// Field represents a field of struct or a method of interface.
+type Field struct {
+	Name       string
+	Type       string // Type of field or signature of method.
+	Doc        string
+	Embedded   bool
+	TagValues  []string // Values of struct tag in the order of keys of the type.
+	Deprecated string   // Deprecation note, empty if not deprecated.
+}
+
+// Note is a marked comment in source code, such as "BUG(who): ...".
+type Note struct {
+	UID  string `json:"uid"` // Author of the note.
+	Body string `json:"body"`
+	URL  string `json:"url"` // VCS URL.
 }
 
 // A File describles declaration of file.
@@ -102,17 +153,41 @@ type File struct {
 
 // PkgDecl is package declaration in database acceptable form.
 type PkgDecl struct {
-	Tag string // Current tag of project.
-	Doc string // Package documentation(doc.go).
+	Tag    string // Current tag of project.
+	Doc    string // Package documentation(doc.go).
+	RawDoc string // Package documentation in plain text.
+	// Headings of package documentation.
+	Headings []*DocHeading
 
 	File
 
 	Examples             []*Example // Function or method example.
 	Imports, TestImports []string   // Imports.
 	Files, TestFiles     []*Source  // Source files.
+	IgnoredFiles         []*Source  // Go files excluded by build constraints.
+	LineFmt              string     // Format of line anchors of VCS URLs.
+
+	// Build constraints of Go files keyed by file names.
+	Constraints map[string]string
 
-	Notes []string // Source code notes.
-	Dirs  []string // Subdirectories
+	Notes map[string][]*Note // Source code notes grouped by markers, e.g. "BUG".
+	Dirs  []string           // Subdirectories
+
+	// GOOS/GOARCH pairs that the package has Go files for.
+	Platforms []string
+
+	// Links of package names of imports resolved by type checking,
+	// nil if the package is not type-checked.
+	Idents []*Link
+	// Types of the package implementing interfaces of imported packages.
+	Implementors []*db.Implementor
+
+	Deprecated   string            // Deprecation note of the package.
+	Deprecations map[string]string // Notes of deprecated identifiers keyed by names.
+
+	Flags []*Flag // Command-line flags defined by the command.
+
+	Module *Module // Module of the package, nil if no go.mod file is found.
 }
 
 // Package represents the full documentation and declaration of a project or package.
@@ -144,4 +219,13 @@ type Walker struct {
 	SrcLines map[string][]string // Source file line slices.
 	SrcFiles map[string]*Source
 	Buf      []byte // scratch space for printNode method.
+
+	// Platforms of source files and top-level declarations,
+	// declarations are keyed by names and methods by "Type.Method".
+	filePlatforms map[string][]string
+	declPlatforms map[string]map[string]bool
+
+	// Nil if the package is not type-checked.
+	tpkg  *types.Package
+	tinfo *types.Info
 }
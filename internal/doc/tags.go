@@ -0,0 +1,360 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"go/build"
+	"go/build/constraint"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/macaron.v1"
+
+	"github.com/unknwon/gowalker/internal/db"
+	"github.com/unknwon/gowalker/internal/setting"
+)
+
+// MaxBuildTags is the maximum number of build tags of a documentation view.
+const MaxBuildTags = 5
+
+var (
+	ErrInvalidBuildTags = errors.New("invalid build tags")
+	ErrTooManyBuildTags = fmt.Errorf("too many build tags, at most %d are allowed", MaxBuildTags)
+)
+
+// systemTags are build tags that are satisfied by the toolchain, operating systems
+// and architectures rather than users.
+var systemTags = map[string]bool{
+	"cgo": true, "gc": true, "gccgo": true, "unix": true, "ignore": true,
+
+	"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true,
+	"hurd": true, "illumos": true, "ios": true, "js": true, "linux": true, "nacl": true,
+	"netbsd": true, "openbsd": true, "plan9": true, "solaris": true, "wasip1": true,
+	"windows": true, "zos": true,
+
+	"386": true, "amd64": true, "amd64p32": true, "arm": true, "armbe": true, "arm64": true,
+	"arm64be": true, "loong64": true, "mips": true, "mipsle": true, "mips64": true,
+	"mips64le": true, "mips64p32": true, "mips64p32le": true, "ppc": true, "ppc64": true,
+	"ppc64le": true, "riscv": true, "riscv64": true, "s390": true, "s390x": true,
+	"sparc": true, "sparc64": true, "wasm": true,
+}
+
+// fileConstraint returns build constraint in the header of Go source file,
+// "// +build" lines are used only if there is no "//go:build" line.
+func fileConstraint(data []byte) (constraint.Expr, error) {
+	var goBuild constraint.Expr
+	var plusBuild []constraint.Expr
+	inComment := false
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		switch {
+		case inComment:
+			inComment = !strings.Contains(line, "*/")
+			continue
+		case len(line) == 0:
+			continue
+		case strings.HasPrefix(line, "/*"):
+			inComment = !strings.Contains(line, "*/")
+			continue
+		case !strings.HasPrefix(line, "//"):
+			// Constraints must appear before package clause.
+		case constraint.IsGoBuild(line):
+			x, err := constraint.Parse(line)
+			if err != nil {
+				return nil, err
+			}
+			goBuild = x
+			continue
+		case constraint.IsPlusBuild(line):
+			x, err := constraint.Parse(line)
+			if err != nil {
+				return nil, err
+			}
+			plusBuild = append(plusBuild, x)
+			continue
+		default:
+			continue
+		}
+		break
+	}
+
+	if goBuild != nil || len(plusBuild) == 0 {
+		return goBuild, nil
+	}
+	x := plusBuild[0]
+	for _, y := range plusBuild[1:] {
+		x = &constraint.AndExpr{X: x, Y: y}
+	}
+	return x, nil
+}
+
+// buildConstraints returns build constraints of Go source files keyed by file names.
+// Files with malformed constraints are skipped.
+func (w *Walker) buildConstraints() map[string]string {
+	constraints := make(map[string]string)
+	for name, src := range w.SrcFiles {
+		if x, err := fileConstraint(src.Data()); err == nil && x != nil {
+			constraints[name] = x.String()
+		}
+	}
+	if len(constraints) == 0 {
+		return nil
+	}
+	return constraints
+}
+
+// customBuildTags returns sorted build tags used by given constraints that are
+// set by users, i.e. not for toolchain, operating systems or architectures.
+func customBuildTags(constraints map[string]string) []string {
+	releaseTags := make(map[string]bool)
+	for _, tag := range build.Default.ReleaseTags {
+		releaseTags[tag] = true
+	}
+
+	seen := make(map[string]bool)
+	var tags []string
+	for _, expr := range constraints {
+		x, err := constraint.Parse("//go:build " + expr)
+		if err != nil {
+			continue
+		}
+		x.Eval(func(tag string) bool {
+			if !seen[tag] && !systemTags[tag] && !releaseTags[tag] && !strings.HasPrefix(tag, "go1.") {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+			return false
+		})
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+var buildTagPattern = regexp.MustCompile(`^[\w.]+$`)
+
+// ParseBuildTags parses comma-separated build tags and returns them sorted
+// without duplicates.
+func ParseBuildTags(s string) ([]string, error) {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, tag := range strings.Split(s, ",") {
+		tag = strings.TrimSpace(tag)
+		if len(tag) == 0 || seen[tag] {
+			continue
+		} else if !buildTagPattern.MatchString(tag) {
+			return nil, ErrInvalidBuildTags
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	if len(tags) == 0 {
+		return nil, ErrInvalidBuildTags
+	} else if len(tags) > MaxBuildTags {
+		return nil, ErrTooManyBuildTags
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
+
+// walkWithTags walks stored source files of the package again with extra build tags.
+func walkWithTags(pdoc *Package, tags []string) (*Package, error) {
+	pinfo := *pdoc.PkgInfo
+	lineFmt := pdoc.LineFmt
+	if len(lineFmt) == 0 {
+		lineFmt = "#L%d"
+	}
+	w := &Walker{
+		LineFmt: lineFmt,
+		Pdoc: &Package{
+			PkgInfo: &pinfo,
+			PkgDecl: &PkgDecl{Tag: pdoc.Tag},
+		},
+	}
+
+	srcs := make([]*Source, 0, len(pdoc.Files)+len(pdoc.TestFiles)+len(pdoc.IgnoredFiles))
+	srcs = append(srcs, pdoc.Files...)
+	srcs = append(srcs, pdoc.TestFiles...)
+	srcs = append(srcs, pdoc.IgnoredFiles...)
+	return w.Build(&WalkRes{
+		WalkDepth: WD_All,
+		WalkType:  WT_Memory,
+		WalkMode:  WM_NoReadme,
+		Srcs:      srcs,
+		BuildTags: tags,
+	})
+}
+
+// MaxTagViews is the maximum number of cached documentation views with build
+// tags, the least recently used ones are evicted when it is exceeded.
+const MaxTagViews = 500
+
+var ErrUnknownBuildTags = errors.New("build tags are not used by the package")
+
+// tagView is a generated documentation view of package with build tags.
+type tagView struct {
+	sync.Mutex
+	key string
+	// Doc path is unique for each view so that files of evicted views never
+	// collide with the ones of a new view of the same key.
+	docPath       string
+	saved         bool // Whether JS files are saved.
+	etag          string
+	numExtraFiles int
+	lastUsed      time.Time // Guarded by tagViews.
+}
+
+// tagViewsDir is the directory of JS files of views with build tags under
+// setting.DocsJSPath. Views are only cached in memory, files that are left by
+// previous processes are stale.
+const tagViewsDir = "_tags"
+
+var tagViews = struct {
+	sync.Mutex
+	m     map[string]*tagView // Keyed by import paths and tags.
+	next  int
+	clean sync.Once
+}{m: make(map[string]*tagView)}
+
+// getTagView returns the cached view of key, a new one is added if it does not
+// exist, and the least recently used one is evicted if the cache is full.
+func getTagView(key string) *tagView {
+	tagViews.Lock()
+	defer tagViews.Unlock()
+
+	tagViews.clean.Do(func() {
+		os.RemoveAll(setting.DocsJSPath + tagViewsDir)
+	})
+
+	view := tagViews.m[key]
+	if view == nil {
+		if len(tagViews.m) >= MaxTagViews {
+			var oldest *tagView
+			for _, v := range tagViews.m {
+				if oldest == nil || v.lastUsed.Before(oldest.lastUsed) {
+					oldest = v
+				}
+			}
+			delete(tagViews.m, oldest.key)
+			// The evicted view may be in use.
+			go func() {
+				oldest.Lock()
+				defer oldest.Unlock()
+				oldest.removeFiles()
+			}()
+		}
+
+		tagViews.next++
+		view = &tagView{
+			key:     key,
+			docPath: fmt.Sprintf("%s/%s-%d", tagViewsDir, key, tagViews.next),
+		}
+		tagViews.m[key] = view
+	}
+	view.lastUsed = time.Now()
+	return view
+}
+
+// removeTagView removes the view from cache if it is still cached.
+func removeTagView(view *tagView) {
+	tagViews.Lock()
+	defer tagViews.Unlock()
+	if tagViews.m[view.key] == view {
+		delete(tagViews.m, view.key)
+	}
+}
+
+// jsPaths returns paths of JS files of the view relative to the working directory.
+func (v *tagView) jsPaths() []string {
+	if !v.saved {
+		return nil
+	}
+	paths := make([]string, 0, v.numExtraFiles+1)
+	paths = append(paths, setting.DocsJSPath+v.docPath+".js")
+	for i := 1; i <= v.numExtraFiles; i++ {
+		paths = append(paths, fmt.Sprintf("%s%s-%d.js", setting.DocsJSPath, v.docPath, i))
+	}
+	return paths
+}
+
+// removeFiles removes JS files of the view, it must be called with the view locked.
+func (v *tagView) removeFiles() {
+	for _, p := range v.jsPaths() {
+		os.Remove(p)
+	}
+	v.saved, v.etag, v.numExtraFiles = false, "", 0
+}
+
+// render generates documentation of the package with the tags, which must be used
+// by build constraints of the package. It must be called with the view locked.
+func (v *tagView) render(render macaron.Render, pinfo *db.PkgInfo, tags []string) error {
+	v.removeFiles()
+
+	pdoc, err := loadGob(pinfo.ImportPath)
+	if err != nil {
+		return err
+	}
+	// Only accepting tags of the package bounds number of views.
+	custom := customBuildTags(pdoc.Constraints)
+	for _, tag := range tags {
+		if i := sort.SearchStrings(custom, tag); i == len(custom) || custom[i] != tag {
+			return ErrUnknownBuildTags
+		}
+	}
+
+	if pdoc, err = walkWithTags(pdoc, tags); err != nil {
+		return fmt.Errorf("walk with tags: %v", err)
+	}
+	result, err := renderDocHTML(render, pdoc)
+	if err != nil {
+		return fmt.Errorf("render doc: %v", err)
+	}
+	numExtraFiles := SaveDocPage(v.docPath, result)
+	if numExtraFiles == -1 {
+		return errors.New("save JS file wasn't successful")
+	}
+	v.saved, v.etag, v.numExtraFiles = true, pinfo.Etag, numExtraFiles
+	return nil
+}
+
+// RenderTagView returns paths of JS files of documentation of the package walked
+// with extra build tags. It is generated from stored gob file and cached for each
+// set of tags until the package is updated.
+func RenderTagView(render macaron.Render, pinfo *db.PkgInfo, tags []string) ([]string, error) {
+	view := getTagView(pinfo.ImportPath + "/" + strings.Join(tags, ","))
+	view.Lock()
+	defer view.Unlock()
+
+	if !view.saved || view.etag != pinfo.Etag {
+		if err := view.render(render, pinfo, tags); err != nil {
+			removeTagView(view)
+			return nil, err
+		}
+	}
+
+	paths := view.jsPaths()
+	for i := range paths {
+		paths[i] = "/" + paths[i]
+	}
+	return paths, nil
+}
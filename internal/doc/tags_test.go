@@ -0,0 +1,165 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/unknwon/gowalker/internal/setting"
+)
+
+func TestFileConstraint(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"package a\n", ""},
+		{"// Copyright 2026\n\n//go:build integration && !purego\n\npackage a\n", "integration && !purego"},
+		{"/* License\n*/\n// +build linux darwin\n// +build cgo\n\npackage a\n", "(linux || darwin) && cgo"},
+		{"//go:build appengine\n// +build appengine\n\npackage a\n", "appengine"},
+		{"package a\n\n//go:build ignore\n", ""},
+	}
+	for _, test := range tests {
+		x, err := fileConstraint([]byte(test.src))
+		if err != nil {
+			t.Fatalf("%q: %v", test.src, err)
+		}
+		var got string
+		if x != nil {
+			got = x.String()
+		}
+		if got != test.want {
+			t.Errorf("%q: got %q, want %q", test.src, got, test.want)
+		}
+	}
+}
+
+func TestCustomBuildTags(t *testing.T) {
+	got := customBuildTags(map[string]string{
+		"a.go": "integration && !purego",
+		"b.go": "(linux || darwin) && cgo && go1.18",
+		"c.go": "appengine || ignore",
+	})
+	if want := []string{"appengine", "integration", "purego"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestParseBuildTags(t *testing.T) {
+	tags, err := ParseBuildTags(" purego,integration,purego ")
+	if err != nil {
+		t.Fatal(err)
+	} else if want := []string{"integration", "purego"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("got %v, want %v", tags, want)
+	}
+
+	for _, s := range []string{"", ",", "a b", `"><script>`} {
+		if _, err = ParseBuildTags(s); err != ErrInvalidBuildTags {
+			t.Errorf("%q: got error %v, want %v", s, err, ErrInvalidBuildTags)
+		}
+	}
+	if _, err = ParseBuildTags("a,b,c,d,e,f"); err != ErrTooManyBuildTags {
+		t.Errorf("got error %v, want %v", err, ErrTooManyBuildTags)
+	}
+}
+
+func TestWalkWithTags(t *testing.T) {
+	pdoc := buildTestPackage(t, "github.com/gowalker/db",
+		&Source{SrcName: "db.go", SrcData: []byte("package db\n\nfunc Open() {}\n")},
+		&Source{SrcName: "db_integration.go", SrcData: []byte("//go:build integration\n\npackage db\n\nfunc Reset() {}\n")},
+	)
+
+	funcNames := func(pdoc *Package) (names []string) {
+		for _, f := range pdoc.Funcs {
+			names = append(names, f.Name)
+		}
+		return names
+	}
+	if got, want := funcNames(pdoc), []string{"Open"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Default funcs: got %v, want %v", got, want)
+	}
+	if len(pdoc.IgnoredFiles) != 1 || pdoc.IgnoredFiles[0].SrcName != "db_integration.go" {
+		t.Errorf("Ignored files: got %v", pdoc.IgnoredFiles)
+	}
+	if want := map[string]string{"db_integration.go": "integration"}; !reflect.DeepEqual(pdoc.Constraints, want) {
+		t.Errorf("Constraints: got %v, want %v", pdoc.Constraints, want)
+	}
+
+	tagged, err := walkWithTags(pdoc, []string{"integration"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := funcNames(tagged), []string{"Open", "Reset"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Tagged funcs: got %v, want %v", got, want)
+	}
+}
+
+func TestRenderTagView(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tags")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer func(old string) { setting.DocsGobPath = old }(setting.DocsGobPath)
+	setting.DocsGobPath = dir + "/gob/"
+	defer func(old string) { setting.DocsJSPath = old }(setting.DocsJSPath)
+	setting.DocsJSPath = dir + "/js/"
+
+	pdoc := buildTestPackage(t, "github.com/gowalker/db",
+		&Source{SrcName: "db.go", SrcData: []byte("package db\n\nfunc Open() {}\n")},
+		&Source{SrcName: "db_integration.go", SrcData: []byte("//go:build integration\n\npackage db\n\nfunc Reset() {}\n")},
+	)
+	if err = saveGob(pdoc); err != nil {
+		t.Fatal(err)
+	}
+
+	// Views of tags that are not used by the package are never cached.
+	if _, err = RenderTagView(nil, pdoc.PkgInfo, []string{"integration", "purego"}); err != ErrUnknownBuildTags {
+		t.Fatalf("got error %v, want %v", err, ErrUnknownBuildTags)
+	}
+	tagViews.Lock()
+	_, ok := tagViews.m["github.com/gowalker/db/integration,purego"]
+	tagViews.Unlock()
+	if ok {
+		t.Error("view of unknown tags is cached")
+	}
+}
+
+func TestGetTagView(t *testing.T) {
+	defer func(old string) { setting.DocsJSPath = old }(setting.DocsJSPath)
+	setting.DocsJSPath = os.TempDir() + "/gowalker-tags-test/"
+
+	first := getTagView("github.com/gowalker/db/first")
+	if view := getTagView("github.com/gowalker/db/first"); view != first {
+		t.Fatal("cached view is not reused")
+	}
+	for i := 0; i < MaxTagViews; i++ {
+		getTagView(fmt.Sprintf("github.com/gowalker/db/%d", i))
+	}
+
+	tagViews.Lock()
+	n, evicted := len(tagViews.m), tagViews.m[first.key] == nil
+	tagViews.Unlock()
+	if n != MaxTagViews || !evicted {
+		t.Errorf("got %d views (first evicted %v), want %d views with first evicted", n, evicted, MaxTagViews)
+	}
+	if view := getTagView(first.key); view == first || view.docPath == first.docPath {
+		t.Error("evicted view is reused")
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"math"
+	"sort"
+	"strings"
+)
+
+// gobImporter imports packages from source files in stored gob files of
+// indexed packages.
+type gobImporter struct {
+	fset *token.FileSet
+	pkgs map[string]*types.Package // Nil value means the package is being imported.
+}
+
+func newGobImporter(fset *token.FileSet) *gobImporter {
+	return &gobImporter{
+		fset: fset,
+		pkgs: make(map[string]*types.Package),
+	}
+}
+
+func (imp *gobImporter) Import(path string) (*types.Package, error) {
+	if path == "unsafe" {
+		return types.Unsafe, nil
+	}
+
+	pkg, ok := imp.pkgs[path]
+	if ok {
+		if pkg == nil {
+			return nil, fmt.Errorf("import cycle via %s", path)
+		}
+		return pkg, nil
+	}
+
+	pdoc, err := loadGob(path)
+	if err != nil {
+		return nil, err
+	} else if pdoc.PkgDecl == nil || len(pdoc.Files) == 0 {
+		return nil, fmt.Errorf("no source files stored: %s", path)
+	}
+
+	files := make([]*ast.File, 0, len(pdoc.Files))
+	for _, src := range pdoc.Files {
+		file, err := parser.ParseFile(imp.fset, path+"/"+src.SrcName, src.Data(), 0)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %v", src.SrcName, err)
+		}
+		files = append(files, file)
+	}
+
+	imp.pkgs[path] = nil
+	conf := types.Config{
+		Importer:         imp,
+		FakeImportC:      true,
+		IgnoreFuncBodies: true,
+		Error:            func(error) {}, // Best effort.
+	}
+	pkg, _ = conf.Check(path, imp.fset, files, nil)
+	imp.pkgs[path] = pkg
+	return pkg, nil
+}
+
+// sortedFiles returns files sorted by their names.
+func sortedFiles(files map[string]*ast.File) []*ast.File {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	astFiles := make([]*ast.File, len(names))
+	for i, name := range names {
+		astFiles[i] = files[name]
+	}
+	return astFiles
+}
+
+// typeCheck type-checks given files of the package, and returns links of
+// package names of imports. Resolved objects of identifiers are kept for
+// annotations of declarations and code, which link to where they are defined.
+// Errors are ignored as documentation should be generated as much as possible.
+func (w *Walker) typeCheck(files map[string]*ast.File) []*Link {
+	astFiles := sortedFiles(files)
+
+	conf := types.Config{
+		Importer:    newGobImporter(w.Fset),
+		FakeImportC: true,
+		Error:       func(error) {},
+	}
+	info := &types.Info{
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	pkg, _ := conf.Check(w.Pdoc.ImportPath, w.Fset, astFiles, info)
+	w.tpkg, w.tinfo = pkg, info
+
+	// Package names may be renamed or differ from the last element of import path,
+	// links are only used by code that is not annotated, e.g. examples.
+	var links []*Link
+	seen := make(map[Link]bool)
+	for _, obj := range info.Uses {
+		if obj, ok := obj.(*types.PkgName); ok {
+			link := Link{
+				Name: obj.Name() + ".",
+				Path: obj.Imported().Path(),
+			}
+			if !seen[link] {
+				seen[link] = true
+				links = append(links, &link)
+			}
+		}
+	}
+
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].Name != links[j].Name {
+			return links[i].Name < links[j].Name
+		}
+		return links[i].Path < links[j].Path
+	})
+	return links
+}
+
+// objectAnchor returns anchor name of the object in documentation of its
+// package, or empty if it is not documented. Selection is required to find
+// the type that declares a field.
+func objectAnchor(obj types.Object, sel *types.Selection) string {
+	if !obj.Exported() {
+		return ""
+	} else if obj.Parent() == obj.Pkg().Scope() {
+		return obj.Name()
+	}
+
+	// Anchors of concrete methods are in form of "Type_Method", as headings of
+	// methods in documentation, while fields and interface methods use ".".
+	var recv types.Type
+	sep := "."
+	switch obj := obj.(type) {
+	case *types.Func:
+		if sig, ok := obj.Type().(*types.Signature); ok && sig.Recv() != nil {
+			recv = sig.Recv().Type()
+			if !types.IsInterface(recv) {
+				sep = "_"
+			}
+		}
+	case *types.Var:
+		// Promoted fields are declared by embedded types.
+		if obj.IsField() && sel != nil && len(sel.Index()) == 1 {
+			recv = sel.Recv()
+		}
+	}
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	named, ok := recv.(*types.Named)
+	if !ok || !named.Obj().Exported() || named.Obj().Pkg() != obj.Pkg() {
+		return ""
+	}
+	return named.Obj().Name() + sep + obj.Name()
+}
+
+// objectAnnotation returns annotation of an identifier that refers to obj,
+// which links to where the object is defined. Kind of the annotation is -1
+// if the identifier should not be linked.
+func objectAnnotation(pkg *types.Package, obj types.Object, sel *types.Selection) Annotation {
+	if obj.Pkg() == nil {
+		if obj.Parent() == types.Universe {
+			return Annotation{Kind: BuiltinAnnotation}
+		}
+		return Annotation{Kind: -1}
+	} else if _, ok := obj.Type().(*types.TypeParam); ok {
+		return Annotation{Kind: -1}
+	}
+
+	anchor := objectAnchor(obj, sel)
+	if len(anchor) == 0 {
+		return Annotation{Kind: -1}
+	}
+	a := Annotation{Kind: ExportLinkAnnotation, Anchor: anchor}
+	if obj.Pkg() != pkg {
+		a.ImportPath = obj.Pkg().Path()
+	}
+	return a
+}
+
+// codeAnnotations returns annotations of identifiers in code of the function
+// declaration, which is cut from source starting at the line after the declaration.
+// It returns nil if the package is not type-checked.
+func (w *Walker) codeAnnotations(decl *ast.FuncDecl, code string) []Annotation {
+	if w.tinfo == nil || decl.Body == nil || strings.Contains(code, "\r") {
+		return nil
+	}
+	file := w.Fset.File(decl.Pos())
+	line := file.Line(decl.Pos())
+	src := w.SrcFiles[file.Name()]
+	if src == nil || line >= file.LineCount() {
+		return nil
+	}
+	base := file.Offset(file.LineStart(line + 1))
+	// Code of one-line functions is not cut verbatim.
+	if !strings.HasPrefix(string(src.Data()[base:]), code) {
+		return nil
+	}
+
+	var annotations []Annotation
+	add := func(a Annotation, pos, end token.Pos) {
+		p, e := file.Offset(pos)-base, file.Offset(end)-base
+		if a.Kind == -1 || p < 0 || e > len(code) || e > math.MaxInt16 {
+			return
+		}
+		a.Pos, a.End = int16(p), int16(e)
+		annotations = append(annotations, a)
+	}
+
+	var visit func(ast.Node) bool
+	visit = func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.SelectorExpr:
+			if x, ok := n.X.(*ast.Ident); ok {
+				if pkgName, ok := w.tinfo.Uses[x].(*types.PkgName); ok {
+					// Names of packages that failed to be imported are
+					// linked by the selected names.
+					a := Annotation{Kind: ExportLinkAnnotation, ImportPath: pkgName.Imported().Path()}
+					if obj := w.tinfo.Uses[n.Sel]; obj != nil {
+						a = objectAnnotation(w.tpkg, obj, nil)
+					} else if a.ImportPath == "C" {
+						a.Kind = -1
+					}
+					add(a, x.Pos(), n.End())
+					return false
+				}
+			}
+			if obj := w.tinfo.Uses[n.Sel]; obj != nil {
+				add(objectAnnotation(w.tpkg, obj, w.tinfo.Selections[n]), n.Sel.Pos(), n.Sel.End())
+			}
+			ast.Inspect(n.X, visit)
+			return false
+		case *ast.Ident:
+			if obj := w.tinfo.Uses[n]; obj != nil {
+				add(objectAnnotation(w.tpkg, obj, nil), n.Pos(), n.End())
+			}
+		}
+		return true
+	}
+	ast.Inspect(decl.Body, visit)
+
+	sort.Slice(annotations, func(i, j int) bool {
+		return annotations[i].Pos < annotations[j].Pos
+	})
+	return annotations
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/unknwon/gowalker/internal/db"
+	"github.com/unknwon/gowalker/internal/setting"
+)
+
+func TestWalker_TypeCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer func(old string) { setting.DocsGobPath = old }(setting.DocsGobPath)
+	setting.DocsGobPath = dir + "/"
+
+	// Package name differs from the last element of its import path.
+	dep := &Package{
+		PkgInfo: &db.PkgInfo{ImportPath: "gopkg.in/yaml.v2"},
+		PkgDecl: &PkgDecl{
+			Files: []*Source{{
+				SrcName: "yaml.go",
+				SrcData: []byte("package yaml\n\ntype Node struct{}\n\nfunc Marshal(v interface{}) ([]byte, error) { return nil, nil }\n"),
+			}},
+		},
+	}
+	if err = saveGob(dep); err != nil {
+		t.Fatal(err)
+	}
+
+	src := `package conf
+
+import (
+	y "gopkg.in/yaml.v2"
+	. "gopkg.in/yaml.v2"
+	"github.com/gowalker/missing-go"
+)
+
+type Config struct {
+	Root  *y.Node
+	Extra Node
+	Other missing.Thing
+}
+
+func Load() *Config {
+	Node := 1
+	c := &Config{}
+	y.Marshal(c.Root, Node)
+	return c
+}
+
+type Store interface {
+	Get() *Config
+}
+
+func (c *Config) Reload() {}
+
+func Use(s Store) {
+	s.Get().Reload()
+}
+`
+	w := &Walker{
+		Fset:     token.NewFileSet(),
+		Pdoc:     &Package{PkgInfo: &db.PkgInfo{ImportPath: "github.com/gowalker/conf"}},
+		SrcFiles: map[string]*Source{"conf.go": {SrcName: "conf.go", BrowseUrl: "conf.go", SrcData: []byte(src)}},
+		SrcLines: make(map[string][]string),
+	}
+	file, err := parser.ParseFile(w.Fset, "conf.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Packages failed to import are left to be guessed by import paths.
+	links := w.typeCheck(map[string]*ast.File{"conf.go": file})
+	if len(links) != 1 || *links[0] != (Link{Name: "y.", Path: "gopkg.in/yaml.v2"}) {
+		t.Errorf("links = %v", links)
+	}
+
+	annotated := func(code Code) map[string]Annotation {
+		m := make(map[string]Annotation)
+		for _, a := range code.Annotations {
+			if a.Kind == ExportLinkAnnotation {
+				m[code.Text[a.Pos:a.End]] = Annotation{Kind: a.Kind, ImportPath: a.ImportPath, Anchor: a.Anchor}
+			}
+		}
+		return m
+	}
+	// Dot-imported names and local variables are resolved by objects rather than names.
+	for _, test := range []struct {
+		code Code
+		want map[string]Annotation
+	}{
+		{
+			code: w.printDecl(file.Decls[1]),
+			want: map[string]Annotation{
+				"y.Node": {Kind: ExportLinkAnnotation, ImportPath: "gopkg.in/yaml.v2"},
+				"Node":   {Kind: ExportLinkAnnotation, ImportPath: "gopkg.in/yaml.v2", Anchor: "Node"},
+			},
+		},
+		{
+			code: func() Code {
+				decl := file.Decls[2].(*ast.FuncDecl)
+				code := w.printCode(decl)
+				return Code{code, w.codeAnnotations(decl, code)}
+			}(),
+			want: map[string]Annotation{
+				"Config":    {Kind: ExportLinkAnnotation, Anchor: "Config"},
+				"y.Marshal": {Kind: ExportLinkAnnotation, ImportPath: "gopkg.in/yaml.v2", Anchor: "Marshal"},
+				"Root":      {Kind: ExportLinkAnnotation, Anchor: "Config.Root"},
+			},
+		},
+		{
+			code: func() Code {
+				decl := file.Decls[5].(*ast.FuncDecl)
+				code := w.printCode(decl)
+				return Code{code, w.codeAnnotations(decl, code)}
+			}(),
+			want: map[string]Annotation{
+				"Get":    {Kind: ExportLinkAnnotation, Anchor: "Store.Get"},
+				"Reload": {Kind: ExportLinkAnnotation, Anchor: "Config_Reload"},
+			},
+		},
+	} {
+		if got := annotated(test.code); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("annotations of %q = %v, want %v", test.code.Text, got, test.want)
+		}
+	}
+}
+
+func TestImportLinks(t *testing.T) {
+	pdoc := &Package{
+		PkgDecl: &PkgDecl{
+			Idents: []*Link{{Name: "y.", Path: "gopkg.in/yaml.v2"}},
+		},
+	}
+	links := importLinks(pdoc, []string{"C", "gopkg.in/yaml.v2", "os"})
+	if len(links) != 2 || links[0].Name != "y." || links[1].Name != "os." || links[1].Path != "os" {
+		t.Errorf("links = %v", links)
+	}
+}
@@ -110,7 +110,7 @@ func downloadGit(schemes []string, repo, savedEtag string) (string, string, erro
 	}
 
 	if scheme == "" {
-		return "", "", com.NotFoundError{"VCS not found"}
+		return "", "", com.NotFoundError{Message: "VCS not found"}
 	}
 
 	tags := make(map[string]string)
@@ -189,7 +189,7 @@ func getVCSDoc(match map[string]string, etagSaved string) (*Package, error) {
 
 	cmd := vcsCmds[match["vcs"]]
 	if cmd == nil {
-		return nil, com.NotFoundError{com.Expand("VCS not supported: {vcs}", match)}
+		return nil, com.NotFoundError{Message: com.Expand("VCS not supported: {vcs}", match)}
 	}
 
 	scheme := match["scheme"]
@@ -227,7 +227,7 @@ func getVCSDoc(match map[string]string, etagSaved string) (*Package, error) {
 	f, err := os.Open(d)
 	if err != nil {
 		if os.IsNotExist(err) {
-			err = com.NotFoundError{err.Error()}
+			err = com.NotFoundError{Message: err.Error()}
 		}
 		return nil, err
 	}
@@ -253,6 +253,23 @@ func getVCSDoc(match map[string]string, etagSaved string) (*Package, error) {
 		})
 	}
 
+	// Get license files and go.mod from the package directory or its parent directories.
+	root := path.Join(repoRoot, com.Expand("{repo}.{vcs}", match))
+	pkgDir := strings.TrimPrefix(match["dir"], "/")
+	if len(pkgDir) > 0 {
+		pkgDir += "/"
+	}
+	parents := newNearestFiles(pkgDir)
+	for dir := pkgDir; ; dir, _ = path.Split(strings.TrimSuffix(dir, "/")) {
+		if err := addLocalParentFiles(parents, root, dir, urlTemplate, urlMatch); err != nil {
+			return nil, err
+		}
+		if len(dir) == 0 {
+			break
+		}
+	}
+	files = append(files, parents.all()...)
+
 	// Start generating data.
 	w := &Walker{
 		LineFmt: lineFmt,
@@ -277,6 +294,38 @@ func getVCSDoc(match map[string]string, etagSaved string) (*Package, error) {
 	})
 }
 
+// addLocalParentFiles adds files that apply to packages in subdirectories from
+// the directory of checked out repository.
+func addLocalParentFiles(parents *nearestFiles, root, dir, urlTemplate string, urlMatch map[string]string) error {
+	fis, err := ioutil.ReadDir(path.Join(root, dir))
+	if err != nil {
+		return err
+	}
+
+	// Browse URLs of files are expanded relative to the directory.
+	m := make(map[string]string, len(urlMatch))
+	for k, v := range urlMatch {
+		m[k] = v
+	}
+	m["dir"] = dir
+
+	for _, fi := range fis {
+		if fi.IsDir() || !isParentFile(fi.Name()) {
+			continue
+		}
+		b, err := ioutil.ReadFile(path.Join(root, dir, fi.Name()))
+		if err != nil {
+			return err
+		}
+		parents.add(dir, &Source{
+			SrcName:   fi.Name(),
+			BrowseUrl: com.Expand(urlTemplate, m, fi.Name()),
+			SrcData:   b,
+		})
+	}
+	return nil
+}
+
 var defaultTags = map[string]string{"git": "master", "hg": "default", "svn": "trunk"}
 
 func bestTag(tags map[string]string, defaultTag string) (string, string, error) {
@@ -286,7 +335,7 @@ func bestTag(tags map[string]string, defaultTag string) (string, string, error)
 	if commit, ok := tags[defaultTag]; ok {
 		return defaultTag, commit, nil
 	}
-	return "", "", com.NotFoundError{"Tag or branch not found."}
+	return "", "", com.NotFoundError{Message: "Tag or branch not found."}
 }
 
 // checkDir checks if directory has been appended to slice.
@@ -331,8 +380,30 @@ func getRepoByArchive(match map[string]string, downloadPath string) (bool, strin
 
 	files := make([]com.RawFile, 0, 5)
 	dirs := make([]string, 0, 5)
+	parents := newNearestFiles(dirPrefix)
 	for _, f := range r.File {
 		fileName := f.Name[nameLen+1:]
+		// License files and go.mod may be in parent directories.
+		if d, fn := path.Split(fileName); isParentFile(fn) && strings.HasPrefix(dirPrefix, d) {
+			rc, err := f.Open()
+			if err != nil {
+				return isGoPro, comment, files, dirs,
+					errors.New(downloadPath + " -> open file: " + err.Error())
+			}
+			p, err := ioutil.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				return isGoPro, comment, files, dirs,
+					errors.New(downloadPath + " -> read file: " + err.Error())
+			}
+			parents.add(d, &Source{
+				SrcName:   fn,
+				BrowseUrl: com.Expand(match["browserUrlTpl"], match, fn),
+				SrcData:   p,
+			})
+			continue
+		}
+
 		// Skip directories and files in wrong directories, get them later.
 		if strings.HasSuffix(fileName, "/") || !strings.HasPrefix(fileName, dirPrefix) {
 			continue
@@ -383,5 +454,5 @@ func getRepoByArchive(match map[string]string, downloadPath string) (bool, strin
 			}
 		}
 	}
-	return isGoPro, comment, files, dirs, nil
+	return isGoPro, comment, append(files, parents.all()...), dirs, nil
 }
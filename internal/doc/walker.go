@@ -22,19 +22,26 @@ import (
 	"go/ast"
 	"go/build"
 	"go/doc"
+	"go/format"
 	"go/parser"
 	"go/printer"
 	"go/token"
+	"go/types"
 	"io"
 	"io/ioutil"
 	"os"
 	"path"
 	"regexp"
+	"sort"
 	"strings"
 	"unicode"
 	"unicode/utf8"
 
 	"github.com/unknwon/com"
+
+	"github.com/unknwon/gowalker/internal/base"
+	"github.com/unknwon/gowalker/internal/license"
+	"github.com/unknwon/gowalker/internal/setting"
 )
 
 // WalkDepth indicates how far the process goes.
@@ -69,9 +76,10 @@ type WalkRes struct {
 	WalkDepth
 	WalkType
 	WalkMode
-	RootPath string    // For WT_Local mode.
-	Srcs     []*Source // For WT_Memory mode.
-	BuildAll bool
+	RootPath  string    // For WT_Local mode.
+	Srcs      []*Source // For WT_Memory mode.
+	BuildAll  bool
+	BuildTags []string // Extra build tags.
 }
 
 // ------------------------------
@@ -260,50 +268,96 @@ func (w *Walker) getExamples() {
 			output = ""
 		}
 
-		// play := ""
-		// if e.Play != nil {
-		// 	w.buf = w.buf[:0]
-		// 	if err := format.Node(sliceWriter{&w.buf}, w.fset, e.Play); err != nil {
-		// 		play = err.Error()
-		// 	} else {
-		// 		play = string(w.buf)
-		// 	}
-		// }
+		play := ""
+		if e.Play != nil {
+			w.Buf = w.Buf[:0]
+			if err := format.Node(sliceWriter{&w.Buf}, w.Fset, e.Play); err == nil {
+				play = string(w.Buf)
+			}
+		}
 
 		docs = append(docs, &Example{
-			Name:   e.Name,
-			Doc:    e.Doc,
-			Code:   code,
-			Output: output,
+			Name:      e.Name,
+			Doc:       e.Doc,
+			Code:      code,
+			Play:      play,
+			Output:    output,
+			Unordered: e.Unordered,
 		})
-		//Play:   play
 	}
 
 	w.Pdoc.Examples = docs
 }
 
-func (w *Walker) printDecl(decl ast.Node) string {
+func (w *Walker) printDecl(decl ast.Node) Code {
 	var d Code
-	d, w.Buf = printDecl(decl, w.Fset, w.Buf)
-	return d.Text
+	d, w.Buf = printDecl(decl, w.Fset, w.Buf, w.tinfo, w.tpkg)
+	return d
 }
 
 func (w *Walker) printPos(pos token.Pos) string {
 	position := w.Fset.Position(pos)
 	src := w.SrcFiles[position.Filename]
-	if src == nil || src.BrowseUrl == "" {
+	if src == nil {
 		// src can be nil when line comments are used (//line <file>:<line>).
 		return ""
+	} else if src.BrowseUrl == "" {
+		if !setting.SaveGob {
+			return ""
+		}
+		return SourceURL(w.Pdoc.ImportPath, src.SrcName) + fmt.Sprintf("#L%d", position.Line)
 	}
 	return src.BrowseUrl + fmt.Sprintf(w.LineFmt, position.Line)
 }
 
+// typeParams returns type parameters declared by owner.
+func (w *Walker) typeParams(owner string, fields *ast.FieldList) (tps []*TypeParam) {
+	if fields == nil {
+		return nil
+	}
+	for _, f := range fields.List {
+		constraint := w.printNode(f.Type)
+		for _, name := range f.Names {
+			tps = append(tps, &TypeParam{
+				Name:       name.Name,
+				Anchor:     TypeParamAnchor(owner, name.Name),
+				Constraint: constraint,
+			})
+		}
+	}
+	return tps
+}
+
+// notes returns source code notes grouped by markers.
+func (w *Walker) notes(ndocs map[string][]*doc.Note) map[string][]*Note {
+	if len(ndocs) == 0 {
+		return nil
+	}
+
+	notes := make(map[string][]*Note, len(ndocs))
+	for marker, nds := range ndocs {
+		for _, n := range nds {
+			notes[marker] = append(notes[marker], &Note{
+				UID:  n.UID,
+				Body: strings.TrimSpace(n.Body),
+				URL:  w.printPos(n.Pos),
+			})
+		}
+	}
+	return notes
+}
+
 func (w *Walker) values(vdocs []*doc.Value) (vals []*Value) {
 	for _, d := range vdocs {
+		decl := w.printDecl(d.Decl)
 		vals = append(vals, &Value{
-			Decl: w.printDecl(d.Decl),
-			URL:  w.printPos(d.Decl.Pos()),
-			Doc:  d.Doc,
+			Names:       d.Names,
+			Decl:        decl.Text,
+			Annotations: decl.Annotations,
+			URL:         w.printPos(d.Decl.Pos()),
+			Platforms:   w.posPlatforms(d.Decl.Pos()),
+			Doc:         d.Doc,
+			Deprecated:  deprecationNote(d.Doc),
 		})
 	}
 
@@ -357,6 +411,8 @@ CutCode:
 func (w *Walker) funcs(fdocs []*doc.Func) (funcs []*Func, ifuncs []*Func) {
 	isBuiltIn := w.Pdoc.ImportPath == "builtin"
 	for _, d := range fdocs {
+		decl := w.printDecl(d.Decl)
+		code := w.printCode(d.Decl)
 		if unicode.IsUpper(rune(d.Name[0])) || isBuiltIn {
 			// var exampleName string
 			// switch {
@@ -368,11 +424,16 @@ func (w *Walker) funcs(fdocs []*doc.Func) (funcs []*Func, ifuncs []*Func) {
 			// 	exampleName = d.Recv + "_" + d.Name
 			// }
 			funcs = append(funcs, &Func{
-				Decl: w.printDecl(d.Decl),
-				URL:  w.printPos(d.Decl.Pos()),
-				Doc:  d.Doc,
-				Name: d.Name,
-				Code: w.printCode(d.Decl),
+				Decl:            decl.Text,
+				Annotations:     decl.Annotations,
+				URL:             w.printPos(d.Decl.Pos()),
+				Doc:             d.Doc,
+				Name:            d.Name,
+				Code:            code,
+				CodeAnnotations: w.codeAnnotations(d.Decl, code),
+				Platforms:       w.declPlatformsOf(funcKey(d.Decl)),
+				TypeParams:      w.typeParams(d.Name, d.Decl.Type.TypeParams),
+				Deprecated:      deprecationNote(d.Doc),
 				// Recv:     d.Recv,
 				// Examples: w.getExamples(exampleName),
 			})
@@ -380,11 +441,14 @@ func (w *Walker) funcs(fdocs []*doc.Func) (funcs []*Func, ifuncs []*Func) {
 		}
 
 		ifuncs = append(ifuncs, &Func{
-			Decl: w.printDecl(d.Decl),
-			URL:  w.printPos(d.Decl.Pos()),
-			Doc:  d.Doc,
-			Name: d.Name,
-			Code: w.printCode(d.Decl),
+			Decl:            decl.Text,
+			Annotations:     decl.Annotations,
+			URL:             w.printPos(d.Decl.Pos()),
+			Doc:             d.Doc,
+			Name:            d.Name,
+			Code:            code,
+			CodeAnnotations: w.codeAnnotations(d.Decl, code),
+			Platforms:       w.declPlatformsOf(funcKey(d.Decl)),
 		})
 	}
 
@@ -397,34 +461,52 @@ func (w *Walker) types(tdocs []*doc.Type) (tps []*Type, itps []*Type) {
 		funcs, ifuncs := w.funcs(d.Funcs)
 		meths, imeths := w.funcs(d.Methods)
 
+		var typeParams []*TypeParam
+		if spec, ok := d.Decl.Specs[0].(*ast.TypeSpec); ok {
+			typeParams = w.typeParams(d.Name, spec.TypeParams)
+		}
+		decl := w.printDecl(d.Decl)
+
 		if unicode.IsUpper(rune(d.Name[0])) || isBuiltIn {
+			fields, ifaceMethods, tagKeys := w.fields(d.Decl)
 			tps = append(tps, &Type{
-				Doc:      d.Doc,
-				Name:     d.Name,
-				Decl:     w.printDecl(d.Decl),
-				URL:      w.printPos(d.Decl.Pos()),
-				Consts:   w.values(d.Consts),
-				Vars:     w.values(d.Vars),
-				Funcs:    funcs,
-				IFuncs:   ifuncs,
-				Methods:  meths,
-				IMethods: imeths,
+				Doc:         d.Doc,
+				Name:        d.Name,
+				Decl:        decl.Text,
+				Annotations: decl.Annotations,
+				URL:         w.printPos(d.Decl.Pos()),
+				Platforms:   w.declPlatformsOf(d.Name),
+				TypeParams:  typeParams,
+				Deprecated:  deprecationNote(d.Doc),
+				Consts:      w.values(d.Consts),
+				Vars:        w.values(d.Vars),
+				Funcs:       funcs,
+				IFuncs:      ifuncs,
+				Methods:     meths,
+				IMethods:    imeths,
+
+				Fields:           fields,
+				TagKeys:          tagKeys,
+				InterfaceMethods: ifaceMethods,
 				// Examples: w.getExamples(d.Name),
 			})
 			continue
 		}
 
 		itps = append(itps, &Type{
-			Doc:      d.Doc,
-			Name:     d.Name,
-			Decl:     w.printDecl(d.Decl),
-			URL:      w.printPos(d.Decl.Pos()),
-			Consts:   w.values(d.Consts),
-			Vars:     w.values(d.Vars),
-			Funcs:    funcs,
-			IFuncs:   ifuncs,
-			Methods:  meths,
-			IMethods: imeths,
+			Doc:         d.Doc,
+			Name:        d.Name,
+			Decl:        decl.Text,
+			Annotations: decl.Annotations,
+			URL:         w.printPos(d.Decl.Pos()),
+			Platforms:   w.declPlatformsOf(d.Name),
+			TypeParams:  typeParams,
+			Consts:      w.values(d.Consts),
+			Vars:        w.values(d.Vars),
+			Funcs:       funcs,
+			IFuncs:      ifuncs,
+			Methods:     meths,
+			IMethods:    imeths,
 		})
 	}
 	return tps, itps
@@ -439,10 +521,28 @@ func (w *Walker) isCgo() bool {
 	return false
 }
 
-var goEnvs = []struct{ GOOS, GOARCH string }{
-	{"linux", "amd64"},
-	{"darwin", "amd64"},
-	{"windows", "amd64"},
+// ignoredFiles returns Go files that are not used by the package for any platform.
+func (w *Walker) ignoredFiles(bpkg *build.Package) []*Source {
+	used := make(map[string]bool)
+	for _, names := range [][]string{bpkg.GoFiles, bpkg.CgoFiles, bpkg.TestGoFiles, bpkg.XTestGoFiles} {
+		for _, name := range names {
+			used[name] = true
+		}
+	}
+
+	var names []string
+	for name := range w.SrcFiles {
+		if !used[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	srcs := make([]*Source, len(names))
+	for i, name := range names {
+		srcs[i] = w.SrcFiles[name]
+	}
+	return srcs
 }
 
 // Build generates documentation from given source files through 'WalkType'.
@@ -450,7 +550,7 @@ func (w *Walker) Build(wr *WalkRes) (*Package, error) {
 	ctxt := build.Context{
 		CgoEnabled:  true,
 		ReleaseTags: build.Default.ReleaseTags,
-		BuildTags:   build.Default.BuildTags,
+		BuildTags:   append(append([]string{}, build.Default.BuildTags...), wr.BuildTags...),
 		Compiler:    "gc",
 	}
 
@@ -474,11 +574,19 @@ func (w *Walker) Build(wr *WalkRes) (*Package, error) {
 		// Convert source files.
 		w.SrcFiles = make(map[string]*Source)
 		w.Pdoc.Readme = make(map[string][]byte)
+		licenses := make(map[string][]byte)
 		for _, src := range wr.Srcs {
 			srcName := strings.ToLower(src.Name()) // For readme comparation.
 			switch {
 			case strings.HasSuffix(src.Name(), ".go"):
 				w.SrcFiles[src.Name()] = src
+			case src.Name() == "go.mod":
+				// Documentation is still useful without module information.
+				if mod, err := parseGoMod(src.Data()); err == nil {
+					w.Pdoc.Module = mod
+				}
+			case base.IsLicenseFile(src.Name()):
+				licenses[src.Name()] = src.Data()
 			case len(w.Pdoc.Tag) > 0 || (wr.WalkMode&WM_NoReadme != 0):
 				// This means we are not on the latest version of the code,
 				// so we do not collect the README files.
@@ -490,6 +598,8 @@ func (w *Walker) Build(wr *WalkRes) (*Package, error) {
 			}
 		}
 
+		w.Pdoc.License, w.Pdoc.LicenseConfidence = license.Detect(licenses)
+
 		// Check source files.
 		if w.SrcFiles == nil {
 			return nil, errors.New("WT_Memory: no Go source file")
@@ -501,29 +611,20 @@ func (w *Walker) Build(wr *WalkRes) (*Package, error) {
 		return nil, errors.New("Hasn't supported yet!")
 	}
 
-	var err error
-	var bpkg *build.Package
-
-	for _, env := range goEnvs {
-		ctxt.GOOS = env.GOOS
-		ctxt.GOARCH = env.GOARCH
-
-		bpkg, err = ctxt.ImportDir(w.Pdoc.ImportPath, 0)
-		// Continue if there are no Go source files; we still want the directory info.
-		_, nogo := err.(*build.NoGoError)
-		if err != nil {
-			if nogo {
-				err = nil
-			} else {
-				return nil, errors.New("Walker.Build -> ImportDir: " + err.Error())
-			}
-		}
+	bpkg, err := w.importDir(ctxt, setting.Platforms)
+	if err != nil {
+		return nil, errors.New("Walker.Build -> " + err.Error())
 	}
 
 	w.Pdoc.IsCmd = bpkg.IsCommand()
 	w.Pdoc.Synopsis = synopsis(bpkg.Doc)
 
 	w.Pdoc.Imports = bpkg.Imports
+	if w.Pdoc.Module != nil {
+		w.Pdoc.ModulePath = w.Pdoc.Module.Path
+		w.Pdoc.GoVersion = w.Pdoc.Module.GoVersion
+		w.Pdoc.ImportVersions = importVersions(w.Pdoc.Module, w.Pdoc.Imports)
+	}
 	w.Pdoc.IsCgo = w.isCgo()
 	w.Pdoc.TestImports = bpkg.TestImports
 
@@ -532,6 +633,10 @@ func (w *Walker) Build(wr *WalkRes) (*Package, error) {
 		return w.Pdoc, nil
 	}
 
+	w.Pdoc.LineFmt = w.LineFmt
+	w.Pdoc.Constraints = w.buildConstraints()
+	w.Pdoc.IgnoredFiles = w.ignoredFiles(bpkg)
+
 	w.Fset = token.NewFileSet()
 	// Parse the Go files
 	files := make(map[string]*ast.File)
@@ -539,7 +644,6 @@ func (w *Walker) Build(wr *WalkRes) (*Package, error) {
 		file, err := parser.ParseFile(w.Fset, name, w.SrcFiles[name].Data(), parser.ParseComments)
 		if err != nil {
 			return nil, errors.New("Walker.Build -> parse Go files: " + err.Error())
-			continue
 		}
 		w.Pdoc.Files = append(w.Pdoc.Files, w.SrcFiles[name])
 		// w.Pdoc.SourceSize += int64(len(w.SrcFiles[name].Data()))
@@ -547,13 +651,24 @@ func (w *Walker) Build(wr *WalkRes) (*Package, error) {
 	}
 
 	w.apkg, _ = ast.NewPackage(w.Fset, files, poorMansImporter, nil)
+	w.collectDeclPlatforms(files)
+	if w.Pdoc.IsCmd {
+		w.Pdoc.Flags = w.flags(files)
+	}
+	// Type-check before the AST is filtered by go/doc.
+	var localPkg *types.Package
+	if setting.TypeCheck {
+		w.Pdoc.Idents = w.typeCheck(files)
+	} else {
+		// Only types embedded from the same package can be resolved.
+		localPkg = checkLocal(w.Pdoc.ImportPath, w.Fset, files)
+	}
 
 	// Find examples in the test files.
 	for _, name := range append(bpkg.TestGoFiles, bpkg.XTestGoFiles...) {
 		file, err := parser.ParseFile(w.Fset, name, w.SrcFiles[name].Data(), parser.ParseComments)
 		if err != nil {
 			return nil, errors.New("Walker.Build -> find examples: " + err.Error())
-			continue
 		}
 		w.Pdoc.TestFiles = append(w.Pdoc.TestFiles, w.SrcFiles[name])
 		//w.pdoc.TestSourceSize += len(w.srcs[name].data)
@@ -572,12 +687,7 @@ func (w *Walker) Build(wr *WalkRes) (*Package, error) {
 
 	// Get doc.
 	pdoc.Doc = strings.TrimRight(pdoc.Doc, " \t\n\r")
-	var buf bytes.Buffer
-	doc.ToHTML(&buf, pdoc.Doc, nil)
-	w.Pdoc.Doc = buf.String()
-	// Highlight first sentence.
-	w.Pdoc.Doc = strings.Replace(w.Pdoc.Doc, "<p>", "<p><b>", 1)
-	w.Pdoc.Doc = strings.Replace(w.Pdoc.Doc, "</p>", "</b></p>", 1)
+	w.Pdoc.RawDoc = pdoc.Doc
 
 	if wr.WalkMode&WM_NoExample == 0 {
 		w.getExamples()
@@ -587,10 +697,24 @@ func (w *Walker) Build(wr *WalkRes) (*Package, error) {
 	w.Pdoc.Consts = w.values(pdoc.Consts)
 	w.Pdoc.Funcs, w.Pdoc.Ifuncs = w.funcs(pdoc.Funcs)
 	w.Pdoc.Types, w.Pdoc.Itypes = w.types(pdoc.Types)
+	if w.tpkg != nil {
+		w.implementations(w.tpkg)
+		w.promotions(w.tpkg)
+	} else if localPkg != nil {
+		w.promotions(localPkg)
+	}
 	w.Pdoc.Vars = w.values(pdoc.Vars)
 	w.Pdoc.ImportPaths = strings.Join(pdoc.Imports, "|")
 	w.Pdoc.ImportNum = int64(len(pdoc.Imports))
-	//w.Pdoc.Notes = w.notes(pdoc.Notes)
+	w.Pdoc.Notes = w.notes(pdoc.Notes)
+	w.Pdoc.Deprecated = deprecationNote(w.Pdoc.RawDoc)
+	w.Pdoc.IsDeprecated = len(w.Pdoc.Deprecated) > 0
+	w.Pdoc.Deprecations = deprecations(w.Pdoc, pdoc)
+
+	// Doc links are resolved against declarations of the package.
+	r := newDocRenderer(w.Pdoc)
+	w.Pdoc.Doc = r.packageHTML(w.Pdoc.RawDoc)
+	w.Pdoc.Headings = r.headings(w.Pdoc.RawDoc)
 
 	return w.Pdoc, nil
 }
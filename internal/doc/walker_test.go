@@ -0,0 +1,159 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package doc
+
+import (
+	"go/ast"
+	"go/doc"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/unknwon/gowalker/internal/db"
+)
+
+// buildTestPackage walks given source files in memory as the package of importPath.
+func buildTestPackage(t *testing.T, importPath string, srcs ...*Source) *Package {
+	t.Helper()
+	w := &Walker{
+		LineFmt: "#L%d",
+		Pdoc:    &Package{PkgInfo: &db.PkgInfo{ImportPath: importPath}},
+	}
+	pdoc, err := w.Build(&WalkRes{
+		WalkDepth: WD_All,
+		WalkType:  WT_Memory,
+		WalkMode:  WM_NoReadme,
+		Srcs:      srcs,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pdoc
+}
+
+func TestWalker_Notes(t *testing.T) {
+	w := &Walker{
+		Fset:     token.NewFileSet(),
+		LineFmt:  "#L%d",
+		SrcFiles: map[string]*Source{"cache.go": {BrowseUrl: "github.com/gowalker/cache/blob/master/cache.go"}},
+	}
+	file, err := parser.ParseFile(w.Fset, "cache.go", `// Package cache stores things.
+package cache
+
+// TODO(unknwon): evict entries by size.
+
+// Get returns the value of key.
+//
+// BUG(joe): It is not safe for concurrent use.
+func Get(key string) string { return "" }
+
+// NOTE(ann): values are copied.
+// BUG(ann): Keys are case-insensitive.
+`, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	apkg, _ := ast.NewPackage(w.Fset, map[string]*ast.File{"cache.go": file}, poorMansImporter, nil)
+	notes := w.notes(doc.New(apkg, "github.com/gowalker/cache", 0).Notes)
+
+	var got [][]string
+	for _, s := range noteSections(notes) {
+		got = append(got, []string{s.Marker, s.Title})
+		for _, n := range s.Notes {
+			got = append(got, []string{n.UID, n.Body, n.URL})
+		}
+	}
+	want := [][]string{
+		{"BUG", "Bugs"},
+		{"joe", "It is not safe for concurrent use.", "github.com/gowalker/cache/blob/master/cache.go#L8"},
+		{"ann", "Keys are case-insensitive.", "github.com/gowalker/cache/blob/master/cache.go#L12"},
+		{"NOTE", "Notes"},
+		{"ann", "values are copied.", "github.com/gowalker/cache/blob/master/cache.go#L11"},
+		{"TODO", "Todos"},
+		{"unknwon", "evict entries by size.", "github.com/gowalker/cache/blob/master/cache.go#L4"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Notes:\ngot  %v\nwant %v", got, want)
+	}
+}
+
+func TestWalker_Platforms(t *testing.T) {
+	src := func(name, data string) *Source {
+		return &Source{SrcName: name, BrowseUrl: "github.com/gowalker/term/blob/master/" + name, SrcData: []byte(data)}
+	}
+	pdoc := buildTestPackage(t, "github.com/gowalker/term",
+		src("term.go", "// Package term controls terminals.\npackage term\n\nfunc Size() (int, int) { return 0, 0 }\n"),
+		src("term_unix.go", "//go:build linux || darwin\n\npackage term\n\nfunc MakeRaw(fd int) error { return nil }\n\ntype State struct{}\n"),
+		src("term_windows.go", "package term\n\nfunc MakeRaw(fd int) error { return nil }\n\nconst CodePage = 65001\n"),
+	)
+
+	if want := []string{"linux/amd64", "darwin/amd64", "windows/amd64"}; !reflect.DeepEqual(pdoc.Platforms, want) {
+		t.Errorf("Package platforms: got %v, want %v", pdoc.Platforms, want)
+	}
+
+	got := make(map[string][]string)
+	for _, f := range pdoc.Funcs {
+		got[f.Name] = f.Platforms
+	}
+	for _, typ := range pdoc.Types {
+		got[typ.Name] = typ.Platforms
+	}
+	for _, v := range pdoc.Consts {
+		got[v.Names[0]] = v.Platforms
+	}
+	want := map[string][]string{
+		"Size":     nil,
+		"MakeRaw":  nil, // Declared by files of all platforms.
+		"State":    {"linux/amd64", "darwin/amd64"},
+		"CodePage": {"windows/amd64"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Declaration platforms:\ngot  %v\nwant %v", got, want)
+	}
+}
+
+func TestWalker_ExamplePlay(t *testing.T) {
+	pdoc := buildTestPackage(t, "github.com/gowalker/hello",
+		&Source{SrcName: "hello.go", SrcData: []byte("package hello\n\nfunc Hello() string { return \"hi\" }\n")},
+		&Source{SrcName: "example_test.go", SrcData: []byte(`package hello_test
+
+import (
+	"fmt"
+
+	"github.com/gowalker/hello"
+)
+
+func ExampleHello() {
+	fmt.Println(hello.Hello())
+	// Unordered output: hi
+}
+`)},
+	)
+
+	if len(pdoc.Examples) != 1 {
+		t.Fatalf("Examples: got %d, want 1", len(pdoc.Examples))
+	}
+	e := pdoc.Examples[0]
+	if e.Output != "hi\n" || !e.Unordered {
+		t.Errorf("Output: got %q (unordered %v), want %q (unordered true)", e.Output, e.Unordered, "hi\n")
+	}
+	for _, s := range []string{"package main\n", `"github.com/gowalker/hello"`, "func main() {", "fmt.Println(hello.Hello())"} {
+		if !strings.Contains(e.Play, s) {
+			t.Errorf("Play does not contain %q:\n%s", s, e.Play)
+		}
+	}
+}
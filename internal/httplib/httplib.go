@@ -74,6 +74,13 @@ func SetDefaultSetting(setting BeegoHttpSettings) {
 	}
 }
 
+// DefaultSetting returns a copy of current default settings.
+func DefaultSetting() BeegoHttpSettings {
+	settingMutex.Lock()
+	defer settingMutex.Unlock()
+	return defaultSetting
+}
+
 // return *BeegoHttpRequest with specific method
 func newBeegoRequest(url, method string) *BeegoHttpRequest {
 	var resp http.Response
@@ -15,13 +15,25 @@
 package httplib
 
 import (
+	"flag"
 	"io/ioutil"
 	"os"
 	"strings"
 	"testing"
 )
 
+var network = flag.Bool("network", false, "Run tests that send requests to httpbin.org.")
+
+// requireNetwork skips the test unless tests are run with -network flag.
+func requireNetwork(t *testing.T) {
+	if !*network {
+		t.Skip("skipping test that requires network, use -network flag to run")
+	}
+}
+
 func TestResponse(t *testing.T) {
+	requireNetwork(t)
+
 	req := Get("http://httpbin.org/get")
 	resp, err := req.Response()
 	if err != nil {
@@ -31,6 +43,8 @@ func TestResponse(t *testing.T) {
 }
 
 func TestGet(t *testing.T) {
+	requireNetwork(t)
+
 	req := Get("http://httpbin.org/get")
 	b, err := req.Bytes()
 	if err != nil {
@@ -50,6 +64,8 @@ func TestGet(t *testing.T) {
 }
 
 func TestSimplePost(t *testing.T) {
+	requireNetwork(t)
+
 	v := "smallfish"
 	req := Post("http://httpbin.org/post")
 	req.Param("username", v)
@@ -86,6 +102,8 @@ func TestSimplePost(t *testing.T) {
 //}
 
 func TestSimplePut(t *testing.T) {
+	requireNetwork(t)
+
 	str, err := Put("http://httpbin.org/put").String()
 	if err != nil {
 		t.Fatal(err)
@@ -94,6 +112,8 @@ func TestSimplePut(t *testing.T) {
 }
 
 func TestSimpleDelete(t *testing.T) {
+	requireNetwork(t)
+
 	str, err := Delete("http://httpbin.org/delete").String()
 	if err != nil {
 		t.Fatal(err)
@@ -102,6 +122,8 @@ func TestSimpleDelete(t *testing.T) {
 }
 
 func TestWithCookie(t *testing.T) {
+	requireNetwork(t)
+
 	v := "smallfish"
 	str, err := Get("http://httpbin.org/cookies/set?k1=" + v).SetEnableCookie(true).String()
 	if err != nil {
@@ -122,6 +144,8 @@ func TestWithCookie(t *testing.T) {
 }
 
 func TestWithBasicAuth(t *testing.T) {
+	requireNetwork(t)
+
 	str, err := Get("http://httpbin.org/basic-auth/user/passwd").SetBasicAuth("user", "passwd").String()
 	if err != nil {
 		t.Fatal(err)
@@ -134,6 +158,8 @@ func TestWithBasicAuth(t *testing.T) {
 }
 
 func TestWithUserAgent(t *testing.T) {
+	requireNetwork(t)
+
 	v := "beego"
 	str, err := Get("http://httpbin.org/headers").SetUserAgent(v).String()
 	if err != nil {
@@ -148,6 +174,8 @@ func TestWithUserAgent(t *testing.T) {
 }
 
 func TestWithSetting(t *testing.T) {
+	requireNetwork(t)
+
 	v := "beego"
 	var setting BeegoHttpSettings
 	setting.EnableCookie = true
@@ -168,6 +196,8 @@ func TestWithSetting(t *testing.T) {
 }
 
 func TestToJson(t *testing.T) {
+	requireNetwork(t)
+
 	req := Get("http://httpbin.org/ip")
 	resp, err := req.Response()
 	if err != nil {
@@ -192,6 +222,8 @@ func TestToJson(t *testing.T) {
 }
 
 func TestToFile(t *testing.T) {
+	requireNetwork(t)
+
 	f := "beego_testfile"
 	req := Get("http://httpbin.org/ip")
 	err := req.ToFile(f)
@@ -206,6 +238,8 @@ func TestToFile(t *testing.T) {
 }
 
 func TestHeader(t *testing.T) {
+	requireNetwork(t)
+
 	req := Get("http://httpbin.org/headers")
 	req.Header("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_9_0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/31.0.1650.57 Safari/537.36")
 	str, err := req.String()
@@ -0,0 +1,224 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Package httpreplay implements an http.RoundTripper that records HTTP
+// interactions into fixture files and replays them later, so code that talks
+// to remote services can be tested offline.
+//
+// A fixture file is a sequence of interactions, each one starts with a header
+// line followed by the response body:
+//
+//	-- GET https://api.github.com/repos/owner/repo 200 --
+//	{"default_branch": "master"}
+package httpreplay
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Interaction is a recorded HTTP request and the response it received.
+type Interaction struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       []byte
+}
+
+// Transport replays interactions loaded from a fixture file. When it is
+// created by NewRecorder, requests are sent through the underlying transport
+// and the interactions are kept for Save.
+type Transport struct {
+	path string
+	next http.RoundTripper // Only set in record mode.
+
+	lock         sync.Mutex
+	interactions []*Interaction
+}
+
+// Load returns a Transport that replays interactions from given fixture file.
+func Load(path string) (*Transport, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	interactions, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse %q: %v", path, err)
+	}
+	return &Transport{
+		path:         path,
+		interactions: interactions,
+	}, nil
+}
+
+// NewRecorder returns a Transport that sends requests through next and
+// records interactions to be saved into given fixture file.
+func NewRecorder(path string, next http.RoundTripper) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Transport{
+		path: path,
+		next: next,
+	}
+}
+
+// IsRecording returns true if the transport is in record mode.
+func (t *Transport) IsRecording() bool {
+	return t.next != nil
+}
+
+func (t *Transport) find(method, url string) *Interaction {
+	for _, i := range t.interactions {
+		if i.Method == method && i.URL == url {
+			return i
+		}
+	}
+	return nil
+}
+
+func newResponse(req *http.Request, i *Interaction) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.StatusCode, http.StatusText(i.StatusCode)),
+		StatusCode:    i.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          ioutil.NopCloser(bytes.NewReader(i.Body)),
+		ContentLength: int64(len(i.Body)),
+		Request:       req,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	url := req.URL.String()
+	if !t.IsRecording() {
+		t.lock.Lock()
+		i := t.find(req.Method, url)
+		t.lock.Unlock()
+		if i == nil {
+			return nil, fmt.Errorf("httpreplay: no recorded response for %s %s in %q", req.Method, url, t.path)
+		}
+		return newResponse(req, i), nil
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &Interaction{
+		Method:     req.Method,
+		URL:        url,
+		StatusCode: resp.StatusCode,
+		Body:       body,
+	}
+	t.lock.Lock()
+	if t.find(i.Method, i.URL) == nil {
+		t.interactions = append(t.interactions, i)
+	}
+	t.lock.Unlock()
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	return resp, nil
+}
+
+// Save writes recorded interactions into the fixture file.
+// It does nothing if the transport is not in record mode.
+func (t *Transport) Save() error {
+	if !t.IsRecording() {
+		return nil
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if err := os.MkdirAll(filepath.Dir(t.path), os.ModePerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(t.path, Format(t.interactions), 0644)
+}
+
+// Format encodes interactions in fixture file format. A newline is appended
+// to bodies that do not end with one.
+func Format(interactions []*Interaction) []byte {
+	var buf bytes.Buffer
+	for _, i := range interactions {
+		fmt.Fprintf(&buf, "-- %s %s %d --\n", i.Method, i.URL, i.StatusCode)
+		buf.Write(i.Body)
+		if len(i.Body) > 0 && i.Body[len(i.Body)-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes()
+}
+
+// parseHeader parses a line in form of "-- METHOD URL STATUS --".
+func parseHeader(line string) (*Interaction, bool) {
+	if !strings.HasPrefix(line, "-- ") || !strings.HasSuffix(line, " --") {
+		return nil, false
+	}
+	fields := strings.Fields(line[3 : len(line)-3])
+	if len(fields) != 3 {
+		return nil, false
+	}
+	status, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return nil, false
+	}
+	return &Interaction{
+		Method:     fields[0],
+		URL:        fields[1],
+		StatusCode: status,
+	}, true
+}
+
+// Parse decodes interactions from data in fixture file format.
+func Parse(data []byte) ([]*Interaction, error) {
+	var (
+		interactions []*Interaction
+		cur          *Interaction
+	)
+	for n, line := range bytes.SplitAfter(data, []byte("\n")) {
+		if i, ok := parseHeader(strings.TrimSuffix(string(line), "\n")); ok {
+			cur = i
+			interactions = append(interactions, cur)
+			continue
+		} else if cur == nil {
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: content before first interaction", n+1)
+		}
+
+		cur.Body = append(cur.Body, line...)
+	}
+	return interactions, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package httpreplay
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func get(t *testing.T, tr http.RoundTripper, url string) (int, string) {
+	resp, err := (&http.Client{Transport: tr}).Get(url)
+	if err != nil {
+		t.Fatalf("Get %q: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Read body: %v", err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, "path: %s\n-- not a header --\n", r.URL.Path)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "httpreplay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "fixture.txt")
+
+	recorder := NewRecorder(path, nil)
+	if _, body := get(t, recorder, server.URL+"/a?x=1"); body != "path: /a\n-- not a header --\n" {
+		t.Fatalf("Recorded body = %q", body)
+	}
+	get(t, recorder, server.URL+"/missing")
+	if err = recorder.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	server.Close()
+
+	replayer, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if status, body := get(t, replayer, server.URL+"/a?x=1"); status != 200 || body != "path: /a\n-- not a header --\n" {
+		t.Errorf("Replayed %d %q", status, body)
+	}
+	if status, _ := get(t, replayer, server.URL+"/missing"); status != 404 {
+		t.Errorf("Replayed status = %d, want 404", status)
+	}
+	if _, err = (&http.Client{Transport: replayer}).Get(server.URL + "/b"); err == nil {
+		t.Error("Unrecorded request succeeded")
+	}
+}
+
+func TestParse(t *testing.T) {
+	if _, err := Parse([]byte("garbage\n-- GET http://a 200 --\n")); err == nil {
+		t.Error("Content before first interaction is accepted")
+	}
+
+	interactions, err := Parse([]byte("\n-- GET http://a 200 --\nA\n-- POST http://b 500 --\n"))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	} else if len(interactions) != 2 {
+		t.Fatalf("len(interactions) = %d", len(interactions))
+	}
+	if i := interactions[0]; i.Method != "GET" || i.URL != "http://a" || i.StatusCode != 200 || string(i.Body) != "A\n" {
+		t.Errorf("interactions[0] = %+v", i)
+	}
+	if i := interactions[1]; i.Method != "POST" || i.StatusCode != 500 || len(i.Body) != 0 {
+		t.Errorf("interactions[1] = %+v", i)
+	}
+}
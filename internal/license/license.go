@@ -0,0 +1,170 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Package license classifies license files against a bundled corpus of
+// license texts named by their SPDX identifiers.
+package license
+
+import (
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	log "gopkg.in/clog.v1"
+)
+
+// Unknown is the identifier of license files that match no license in corpus.
+const Unknown = "UNKNOWN"
+
+// Threshold is the minimum confidence for a license file to be classified.
+const Threshold = 0.8
+
+// CorpusPath is the directory of license texts, each file is named by the
+// SPDX identifier of license with extension ".txt".
+var CorpusPath = "conf/license"
+
+// nonRedistributable contains licenses that do not clearly permit to
+// redistribute the code.
+var nonRedistributable = map[string]bool{
+	"JSON": true, // "The Software shall be used for Good, not Evil."
+}
+
+type corpusLicense struct {
+	id       string
+	trigrams map[string]bool
+}
+
+var (
+	corpusOnce sync.Once
+	corpus     []*corpusLicense
+)
+
+// words returns lower-cased words of the text, punctuation is ignored.
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// trigrams returns the set of every three consecutive words in the text.
+func trigrams(text string) map[string]bool {
+	ws := words(text)
+	set := make(map[string]bool, len(ws))
+	for i := 0; i+2 < len(ws); i++ {
+		set[ws[i]+" "+ws[i+1]+" "+ws[i+2]] = true
+	}
+	return set
+}
+
+func loadCorpus() {
+	fis, err := ioutil.ReadDir(CorpusPath)
+	if err != nil {
+		log.Error(2, "Fail to read license corpus: %v", err)
+		return
+	}
+	for _, fi := range fis {
+		if fi.IsDir() || path.Ext(fi.Name()) != ".txt" {
+			continue
+		}
+		data, err := ioutil.ReadFile(path.Join(CorpusPath, fi.Name()))
+		if err != nil {
+			log.Error(2, "Fail to read license text '%s': %v", fi.Name(), err)
+			continue
+		}
+		corpus = append(corpus, &corpusLicense{
+			id:       strings.TrimSuffix(fi.Name(), ".txt"),
+			trigrams: trigrams(string(data)),
+		})
+	}
+}
+
+// Classify returns SPDX identifier of the license that the text matches best
+// and the confidence between 0 and 1, which is the fraction of the license
+// text found in given text. It returns Unknown if confidence of the best match
+// is below Threshold.
+func Classify(text []byte) (string, float64) {
+	corpusOnce.Do(loadCorpus)
+
+	input := trigrams(string(text))
+	id, best, size := Unknown, 0.0, 0
+	for _, lic := range corpus {
+		if len(lic.trigrams) == 0 {
+			continue
+		}
+		n := 0
+		for t := range lic.trigrams {
+			if input[t] {
+				n++
+			}
+		}
+		conf := float64(n) / float64(len(lic.trigrams))
+		// Licenses that extend others, e.g. BSD-3-Clause and BSD-2-Clause,
+		// match equally well, prefer the longer one as it is more specific.
+		switch {
+		case conf > best+0.02,
+			conf > best-0.02 && len(lic.trigrams) > size:
+			id, best, size = lic.id, conf, len(lic.trigrams)
+		}
+	}
+	if best < Threshold {
+		return Unknown, best
+	}
+	return id, best
+}
+
+// Detect classifies license files and returns their SPDX identifiers sorted
+// and separated by ", ", and the lowest confidence of them. It returns empty
+// identifier if there is no license file.
+func Detect(files map[string][]byte) (string, float64) {
+	if len(files) == 0 {
+		return "", 0
+	}
+
+	seen := make(map[string]bool)
+	var ids []string
+	minConf := 1.0
+	for _, data := range files {
+		id, conf := Classify(data)
+		if id == Unknown {
+			// The file is not recognized at all.
+			conf = 0
+		}
+		if conf < minConf {
+			minConf = conf
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ", "), minConf
+}
+
+// Redistributable returns true if all licenses in the list of SPDX identifiers
+// are known and permit redistribution.
+func Redistributable(ids string) bool {
+	if len(ids) == 0 {
+		return false
+	}
+	for _, id := range strings.Split(ids, ", ") {
+		if id == Unknown || nonRedistributable[id] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package license
+
+import (
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+)
+
+func init() {
+	CorpusPath = "../../conf/license"
+}
+
+func TestClassify(t *testing.T) {
+	fis, err := ioutil.ReadDir(CorpusPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every license of corpus should be classified as itself.
+	for _, fi := range fis {
+		data, err := ioutil.ReadFile(path.Join(CorpusPath, fi.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := strings.TrimSuffix(fi.Name(), ".txt")
+		if id, conf := Classify(data); id != want || conf < Threshold {
+			t.Errorf("Classify(%s) = %q, %v, want %q", fi.Name(), id, conf, want)
+		}
+	}
+
+	mit, err := ioutil.ReadFile(path.Join(CorpusPath, "MIT.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Copyright lines, re-wrapped lines and punctuation do not matter.
+	text := "The MIT License (MIT)\n\nCopyright (c) 2026 Gopher\n\n" +
+		strings.Join(strings.Fields(string(mit)[len("MIT License"):]), " ")
+	if id, conf := Classify([]byte(text)); id != "MIT" || conf < 0.95 {
+		t.Errorf("Classify(reformatted MIT) = %q, %v", id, conf)
+	}
+
+	if id, _ := Classify([]byte("All rights reserved. Do not copy.")); id != Unknown {
+		t.Errorf("Classify(proprietary) = %q, want %q", id, Unknown)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	read := func(id string) []byte {
+		data, err := ioutil.ReadFile(path.Join(CorpusPath, id+".txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	tests := []struct {
+		files map[string][]byte
+		ids   string
+		known bool
+	}{
+		{nil, "", false},
+		{map[string][]byte{"LICENSE": read("Apache-2.0")}, "Apache-2.0", true},
+		{map[string][]byte{"COPYING": read("GPL-3.0"), "COPYING.LESSER": read("LGPL-3.0")}, "GPL-3.0, LGPL-3.0", true},
+		{map[string][]byte{"LICENSE": read("JSON")}, "JSON", false},
+		{map[string][]byte{"LICENSE": read("MIT"), "LICENSE.third_party": []byte("Proprietary")}, "MIT, UNKNOWN", false},
+	}
+	for _, test := range tests {
+		ids, conf := Detect(test.files)
+		if ids != test.ids {
+			t.Errorf("Detect(%d files) = %q, want %q", len(test.files), ids, test.ids)
+		}
+		if known := Redistributable(ids); known != test.known {
+			t.Errorf("Redistributable(%q) = %v, want %v", ids, known, test.known)
+		}
+		if test.known && conf < Threshold {
+			t.Errorf("Detect(%q) confidence = %v", ids, conf)
+		}
+	}
+}
+
+func TestClassify_Excerpt(t *testing.T) {
+	gpl, err := ioutil.ReadFile(path.Join(CorpusPath, "GPL-3.0.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Quoting the preamble does not license the code.
+	preamble := string(gpl)[:strings.Index(string(gpl), "TERMS AND CONDITIONS")]
+	if id, conf := Classify([]byte(preamble)); id != Unknown {
+		t.Errorf("Classify(GPL-3.0 preamble) = %q, %v, want %q", id, conf, Unknown)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package apiv1
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/unknwon/gowalker/internal/context"
+	"github.com/unknwon/gowalker/internal/doc"
+	"github.com/unknwon/gowalker/internal/setting"
+)
+
+// maxPrewarmFileSize is the maximum size of uploaded go.mod or go.sum in bytes.
+const maxPrewarmFileSize = 1 << 20
+
+var errInvalidToken = errors.New("invalid token")
+
+func prewarmError(ctx *context.Context, status int, err error) {
+	ctx.JSON(status, map[string]interface{}{
+		"error": err.Error(),
+	})
+}
+
+// prewarmAuthorized checks whether pre-warming is enabled and the request has
+// the token, it sends an error response if not.
+func prewarmAuthorized(ctx *context.Context) bool {
+	if !setting.Prewarm.Enabled {
+		prewarmError(ctx, 403, doc.ErrPrewarmDisabled)
+		return false
+	}
+	token := strings.TrimPrefix(ctx.Req.Header.Get("Authorization"), "token ")
+	if len(setting.Prewarm.Token) == 0 ||
+		subtle.ConstantTimeCompare([]byte(token), []byte(setting.Prewarm.Token)) != 1 {
+		prewarmError(ctx, 401, errInvalidToken)
+		return false
+	}
+	return true
+}
+
+// Prewarm accepts go.mod or go.sum as request body or the "file" field of
+// multipart form, and queues a job to crawl packages of listed modules.
+func Prewarm(ctx *context.Context) {
+	if !prewarmAuthorized(ctx) {
+		return
+	}
+
+	var r io.Reader = ctx.Req.Request.Body
+	if strings.HasPrefix(ctx.Req.Header.Get("Content-Type"), "multipart/form-data") {
+		f, _, err := ctx.Req.FormFile("file")
+		if err != nil {
+			prewarmError(ctx, 400, err)
+			return
+		}
+		defer f.Close()
+		r = f
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxPrewarmFileSize))
+	if err != nil {
+		prewarmError(ctx, 400, err)
+		return
+	}
+
+	job, err := doc.Prewarm(data, doc.PrewarmOptions{
+		Client:        ctx.RemoteAddr(),
+		MaxModules:    setting.Prewarm.MaxModules,
+		MaxPackages:   setting.Prewarm.MaxPackages,
+		MaxClientJobs: setting.Prewarm.MaxClientJobs,
+	})
+	if err != nil {
+		switch err {
+		case doc.ErrPrewarmQueueFull:
+			prewarmError(ctx, 503, err)
+		case doc.ErrPrewarmClientLimit:
+			prewarmError(ctx, 429, err)
+		default:
+			prewarmError(ctx, 400, err)
+		}
+		return
+	}
+	ctx.JSON(202, job)
+}
+
+// PrewarmJob sends progress of the pre-warm job.
+func PrewarmJob(ctx *context.Context) {
+	if !prewarmAuthorized(ctx) {
+		return
+	}
+
+	job, err := doc.GetPrewarmJob(ctx.Params(":id"))
+	if err != nil {
+		prewarmError(ctx, 404, err)
+		return
+	}
+	ctx.JSON(200, job)
+}
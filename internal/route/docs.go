@@ -17,6 +17,7 @@ package route
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"path"
 	"strings"
 	"time"
@@ -27,12 +28,16 @@ import (
 	"github.com/unknwon/gowalker/internal/context"
 	"github.com/unknwon/gowalker/internal/db"
 	"github.com/unknwon/gowalker/internal/doc"
+	"github.com/unknwon/gowalker/internal/license"
 	"github.com/unknwon/gowalker/internal/setting"
 )
 
 const (
 	DOCS         = "docs/docs"
 	DOCS_IMPORTS = "docs/imports"
+	DOCS_SOURCE  = "docs/source"
+	DOCS_DIFF    = "docs/diff"
+	DOCS_LINT    = "docs/lint"
 )
 
 // updateHistory updates browser history.
@@ -79,11 +84,44 @@ func handleError(ctx *context.Context, err error) {
 	Home(ctx)
 }
 
+// serveDocset sends docset of the package tree as a gzipped tarball.
+func serveDocset(ctx *context.Context, pinfo *db.PkgInfo) error {
+	archivePath, err := doc.DocsetArchive(ctx.Render, pinfo)
+	if err != nil {
+		return err
+	}
+	ctx.Resp.Header().Set("Content-Type", "application/gzip")
+	ctx.Resp.Header().Set("Content-Disposition", `attachment; filename="`+path.Base(pinfo.ImportPath)+`.tgz"`)
+	http.ServeFile(ctx.Resp, ctx.Req.Request, archivePath)
+	return nil
+}
+
+// importedPackage is a package imported by another one with the version
+// required by go.mod of the importer.
+type importedPackage struct {
+	*db.PkgInfo
+	ModulePath string
+	Version    string
+	VersionURL string
+}
+
+func importedPackages(pinfo *db.PkgInfo) []*importedPackage {
+	pinfos := db.GetPkgInfosByPaths(strings.Split(pinfo.ImportPaths, "|"))
+	pkgs := make([]*importedPackage, len(pinfos))
+	for i := range pinfos {
+		pkgs[i] = &importedPackage{PkgInfo: pinfos[i]}
+		pkgs[i].ModulePath, pkgs[i].Version = pinfo.ImportVersion(pinfos[i].ImportPath)
+		pkgs[i].VersionURL = doc.ModuleVersionURL(pkgs[i].ModulePath, pkgs[i].Version)
+	}
+	return pkgs
+}
+
 func specialHandles(ctx *context.Context, pinfo *db.PkgInfo) bool {
 	// Only show imports.
 	if strings.HasSuffix(ctx.Req.RequestURI, "?imports") {
 		ctx.Data["PageIsImports"] = true
-		ctx.Data["Packages"] = db.GetPkgInfosByPaths(strings.Split(pinfo.ImportPaths, "|"))
+		ctx.Data["HasVersions"] = len(pinfo.ImportVersions) > 0
+		ctx.Data["Packages"] = importedPackages(pinfo)
 		ctx.HTML(200, DOCS_IMPORTS)
 		return true
 	}
@@ -96,6 +134,125 @@ func specialHandles(ctx *context.Context, pinfo *db.PkgInfo) bool {
 		return true
 	}
 
+	// Download docset of the package and its subpackages.
+	if strings.HasSuffix(ctx.Req.RequestURI, "?docset") {
+		if !setting.SaveGob || !doc.DocsetSupported {
+			ctx.NotFound()
+			return true
+		}
+		if err := serveDocset(ctx, pinfo); err != nil {
+			handleError(ctx, err)
+		}
+		return true
+	}
+
+	// Source code notes in JSON.
+	if strings.HasSuffix(ctx.Req.RequestURI, "?notes") {
+		if !setting.SaveGob {
+			ctx.JSON(404, map[string]interface{}{
+				"error": doc.ErrGobNotFound.Error(),
+			})
+			return true
+		}
+		notes, err := doc.PackageNotes(pinfo.ImportPath)
+		if err == doc.ErrGobNotFound {
+			ctx.JSON(404, map[string]interface{}{
+				"error": err.Error(),
+			})
+			return true
+		} else if err != nil {
+			ctx.JSON(500, map[string]interface{}{
+				"error": err.Error(),
+			})
+			return true
+		}
+		ctx.JSON(200, map[string]interface{}{
+			"import_path": pinfo.ImportPath,
+			"notes":       notes,
+		})
+		return true
+	}
+
+	// Run example and return the result in JSON.
+	if name := ctx.Query("play"); len(name) > 0 {
+		result, err := doc.RunExample(pinfo, name)
+		if err != nil {
+			ctx.JSON(500, map[string]interface{}{
+				"error": err.Error(),
+			})
+			return true
+		}
+		ctx.JSON(200, result)
+		return true
+	}
+
+	// Types of other packages that implement the interface in JSON.
+	if name := ctx.Query("implementors"); len(name) > 0 {
+		impls, err := doc.GetImplementors(pinfo.ImportPath, name)
+		if err != nil {
+			ctx.JSON(500, map[string]interface{}{
+				"error": err.Error(),
+			})
+			return true
+		}
+		ctx.JSON(200, map[string]interface{}{
+			"import_path":  pinfo.ImportPath,
+			"interface":    name,
+			"implementors": impls,
+		})
+		return true
+	}
+
+	// Deprecated identifiers and importers that still use them in JSON.
+	if names, ok := ctx.Req.URL.Query()["deprecated"]; ok {
+		if !setting.SaveGob {
+			ctx.NotFound()
+			return true
+		}
+		report, err := doc.GetDeprecationReport(pinfo, names[0])
+		if err == doc.ErrGobNotFound {
+			ctx.JSON(404, map[string]interface{}{
+				"error": err.Error(),
+			})
+			return true
+		} else if err != nil {
+			ctx.JSON(500, map[string]interface{}{
+				"error": err.Error(),
+			})
+			return true
+		}
+		ctx.JSON(200, report)
+		return true
+	}
+
+	// API changes between two versions.
+	if _, ok := ctx.Req.URL.Query()["diff"]; ok {
+		apiDiff(ctx, pinfo)
+		return true
+	}
+
+	// Documentation quality report.
+	if _, ok := ctx.Req.URL.Query()["lint"]; ok {
+		lintReport(ctx, pinfo)
+		return true
+	}
+
+	// Documentation in Markdown.
+	if ctx.Query("format") == "md" {
+		if !setting.SaveGob {
+			ctx.NotFound()
+			return true
+		}
+		data, err := doc.RenderMarkdown(pinfo.ImportPath)
+		if err != nil {
+			handleError(ctx, err)
+			return true
+		}
+		ctx.Resp.Header().Set("Content-Type", "text/markdown; charset=UTF-8")
+		ctx.Resp.Write(data)
+		return true
+	}
+
 	// Refresh documentation.
 	if strings.HasSuffix(ctx.Req.RequestURI, "?refresh") {
 		if !pinfo.CanRefresh() {
@@ -115,9 +272,116 @@ func specialHandles(ctx *context.Context, pinfo *db.PkgInfo) bool {
 	return false
 }
 
+// apiDiff shows API changes of the package between two versions, or sends them
+// in JSON with "format=json".
+func apiDiff(ctx *context.Context, pinfo *db.PkgInfo) {
+	diff, err := doc.DiffAPI(pinfo.ImportPath, ctx.Query("from"), ctx.Query("to"))
+	if err != nil && err != doc.ErrAPIVersionNotFound {
+		handleError(ctx, err)
+		return
+	}
+
+	if ctx.Query("format") == "json" {
+		if err != nil {
+			ctx.JSON(404, map[string]interface{}{
+				"error": err.Error(),
+			})
+			return
+		}
+		ctx.JSON(200, diff)
+		return
+	}
+
+	versions, err := doc.APIVersions(pinfo.ImportPath)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+	// Newest versions come first.
+	for i, j := 0, len(versions)-1; i < j; i, j = i+1, j-1 {
+		versions[i], versions[j] = versions[j], versions[i]
+	}
+	ctx.Data["Versions"] = versions
+	ctx.Data["Diff"] = diff
+	ctx.HTML(200, DOCS_DIFF)
+}
+
+// lintReport shows documentation quality report of the package, or sends it
+// in JSON with "format=json".
+func lintReport(ctx *context.Context, pinfo *db.PkgInfo) {
+	if !setting.SaveGob {
+		ctx.NotFound()
+		return
+	}
+
+	report, err := doc.Lint(pinfo.ImportPath)
+	if ctx.Query("format") == "json" {
+		if err == doc.ErrGobNotFound {
+			ctx.JSON(404, map[string]interface{}{
+				"error": err.Error(),
+			})
+			return
+		} else if err != nil {
+			ctx.JSON(500, map[string]interface{}{
+				"error": err.Error(),
+			})
+			return
+		}
+		ctx.JSON(200, report)
+		return
+	} else if err == doc.ErrGobNotFound {
+		ctx.NotFound()
+		return
+	} else if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	ctx.Data["Report"] = report
+	ctx.Data["NumUndocumented"] = report.Count(doc.LintUndocumented)
+	ctx.Data["NumCommentPrefix"] = report.Count(doc.LintCommentPrefix)
+	ctx.Data["NumUnusedExample"] = report.Count(doc.LintUnusedExample)
+	ctx.Data["NumBrokenLink"] = report.Count(doc.LintBrokenLink)
+	ctx.HTML(200, DOCS_LINT)
+}
+
+// sourceFile shows highlighted source file of the package.
+func sourceFile(c *context.Context, importPath, name string) {
+	pinfo, err := doc.CheckPackage(importPath, c.Render, doc.RequestTypeHuman)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	file, err := doc.RenderSource(pinfo.ImportPath, name)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Title(pinfo.ImportPath + "/" + name)
+	c.Data["ParentPath"] = path.Dir(pinfo.ImportPath)
+	c.Data["ProjectName"] = path.Base(pinfo.ImportPath)
+	c.Data["ProjectPath"] = pinfo.ProjectPath
+	c.Data["NumStars"] = pinfo.Stars
+	c.Data["ParentLink"] = "/" + pinfo.ImportPath
+	c.Data["File"] = file
+	// GitHub redirects non-HTTPS link and Safari loses "#XXX".
+	if strings.HasPrefix(pinfo.ProjectPath, "github") {
+		c.Data["Secure"] = "s"
+	}
+	c.Success(DOCS_SOURCE)
+}
+
 func Docs(c *context.Context) {
 	importPath := c.Params("*")
 
+	// Source files are viewed at "/<import path>/-/src/<file name>".
+	if i := strings.Index(importPath, "/-/src/"); i > -1 {
+		sourceFile(c, importPath[:i], importPath[i+len("/-/src/"):])
+		return
+	}
+
 	// Check if import path looks like a vendor directory
 	if strings.Contains(importPath, "/vendor/") {
 		handleError(c, errors.New("import path looks like is a vendor directory, don't try to fool me! :D"))
@@ -152,6 +416,16 @@ func Docs(c *context.Context) {
 
 	c.Data["PkgDesc"] = pinfo.Synopsis
 
+	c.Data["License"] = pinfo.License
+	c.Data["LicenseConfidence"] = int(pinfo.LicenseConfidence * 100)
+	c.Data["IsLicenseRedistributable"] = license.Redistributable(pinfo.License)
+
+	// The standard library is not versioned as a module.
+	if !pinfo.IsGoRepo {
+		c.Data["ModulePath"] = pinfo.ModulePath
+		c.Data["GoVersion"] = pinfo.GoVersion
+	}
+
 	// README
 	lang := c.Data["Lang"].(string)[:2]
 	readmePath := setting.DocsJSPath + pinfo.ImportPath + "_RM_" + lang + ".js"
@@ -182,6 +456,19 @@ func Docs(c *context.Context) {
 		}
 		c.Data["DocJS"] = docJS
 	}
+
+	// Documentation with extra build tags.
+	if c.Query("tags") != "" {
+		tags, err := doc.ParseBuildTags(c.Query("tags"))
+		if err == nil {
+			c.Data["DocJS"], err = doc.RenderTagView(c.Render, pinfo, tags)
+		}
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+		c.Flash.Info(c.Tr("docs.build_tags", strings.Join(tags, ","), c.Data["Link"]), true)
+	}
 	c.Data["Timestamp"] = pinfo.Created
 	if time.Now().UTC().Add(-5*time.Second).Unix() < pinfo.Created {
 		c.Flash.Success(c.Tr("docs.generate_success"), true)
@@ -191,7 +478,7 @@ func Docs(c *context.Context) {
 	if len(pinfo.Subdirs) > 0 {
 		c.Data["IsHasSubdirs"] = true
 		c.Data["ViewDirPath"] = pinfo.ViewDirPath
-		c.Data["Subdirs"] = db.GetSubPkgs(pinfo.ImportPath, strings.Split(pinfo.Subdirs, "|"))
+		c.Data["Subdirs"] = pinfo.GetSubPkgs()
 	}
 
 	// Imports and references
@@ -201,6 +488,9 @@ func Docs(c *context.Context) {
 	// Tools
 	c.Data["TimeDuration"] = base.TimeSince(time.Unix(pinfo.Created, 0), c.Locale.Language())
 	c.Data["CanRefresh"] = pinfo.CanRefresh()
+	c.Data["CanDownloadDocset"] = setting.SaveGob && doc.DocsetSupported
+	c.Data["CanDiffAPI"] = setting.SaveGob
+	c.Data["CanLint"] = setting.SaveGob
 
 	updateHistory(c, pinfo.ID)
 
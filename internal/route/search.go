@@ -72,6 +72,7 @@ type searchResult struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	URL         string `json:"url"`
+	License     string `json:"license,omitempty"`
 }
 
 func SearchJSON(ctx *context.Context) {
@@ -94,6 +95,7 @@ func SearchJSON(ctx *context.Context) {
 			Title:       pinfos[i].ImportPath,
 			Description: pinfos[i].Synopsis,
 			URL:         "/" + pinfos[i].ImportPath,
+			License:     pinfos[i].License,
 		}
 	}
 
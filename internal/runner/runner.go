@@ -0,0 +1,291 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Package runner implements a local service that builds and runs example programs
+// in a sandbox.
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	log "gopkg.in/clog.v1"
+)
+
+const (
+	maxRequestSize = 10 << 20
+	maxOutputSize  = 64 << 10
+	buildTimeout   = 2 * time.Minute
+)
+
+// Request is a program to be built and run.
+type Request struct {
+	ImportPath string            `json:"import_path"`
+	Files      map[string]string `json:"files"` // Source files of the package keyed by names.
+	Program    string            `json:"program"`
+}
+
+// Response is the result of a program.
+type Response struct {
+	Output   string `json:"output"`
+	Errors   string `json:"errors"` // Build or runtime errors.
+	ExitCode int    `json:"exit_code"`
+	TimedOut bool   `json:"timed_out"`
+}
+
+// Config contains limits and toolchain of the runner.
+type Config struct {
+	GoBin       string        // Path of go command.
+	Timeout     time.Duration // Wall time limit of running program.
+	MaxCPU      int           // CPU time limit in seconds.
+	MaxMemory   int           // Data segment limit in megabytes.
+	MaxProcs    int           // Limit of processes and threads of the user running programs.
+	MaxFileSize int           // Limit of file size and writable space in megabytes.
+	// Unprivileged user and group to run programs when runner is started by root,
+	// otherwise programs run as the user of runner in a user namespace.
+	UID, GID int
+}
+
+// Runner builds and runs programs, it serves requests over HTTP.
+type Runner struct {
+	cfg  Config
+	self string // Path of current executable to start sandbox.
+	sem  chan struct{}
+}
+
+// New returns a new runner with given configuration.
+func New(cfg Config) (*Runner, error) {
+	if len(cfg.GoBin) == 0 {
+		cfg.GoBin = "go"
+	}
+	if os.Getuid() == 0 {
+		if cfg.UID <= 0 || cfg.GID <= 0 {
+			return nil, errors.New("programs cannot be run as root")
+		}
+	} else {
+		cfg.UID, cfg.GID = 0, 0
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("get executable: %v", err)
+	}
+	return &Runner{
+		cfg:  cfg,
+		self: self,
+		sem:  make(chan struct{}, runtime.NumCPU()),
+	}, nil
+}
+
+func (r *Runner) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost || req.URL.Path != "/run" {
+		http.NotFound(w, req)
+		return
+	}
+
+	var preq Request
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxRequestSize)).Decode(&preq); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	r.sem <- struct{}{}
+	resp, err := r.Run(req.Context(), &preq)
+	<-r.sem
+	if err != nil {
+		log.Error(2, "Run program of '%s': %v", preq.ImportPath, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// isValidFileName returns true if name is a plain Go source file name.
+func isValidFileName(name string) bool {
+	return strings.HasSuffix(name, ".go") && name == path.Base(name) && !strings.HasPrefix(name, ".")
+}
+
+// prepare writes program and package files into a new module in dir,
+// which is also the root directory of the sandbox.
+func prepare(dir string, req *Request) error {
+	// Programs may run as another user.
+	if err := os.Chmod(dir, 0755); err != nil {
+		return err
+	} else if err = os.Mkdir(filepath.Join(dir, "tmp"), 0755); err != nil {
+		return err
+	}
+
+	gomod := "module play\n"
+	if len(req.Files) > 0 {
+		gomod += fmt.Sprintf("\nrequire %s v0.0.0\n\nreplace %[1]s => ./pkg\n", req.ImportPath)
+
+		pkgDir := filepath.Join(dir, "pkg")
+		if err := os.Mkdir(pkgDir, 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(pkgDir, "go.mod"), []byte("module "+req.ImportPath+"\n"), 0644); err != nil {
+			return err
+		}
+		for name, data := range req.Files {
+			if !isValidFileName(name) {
+				return fmt.Errorf("invalid file name %q", name)
+			}
+			if err := ioutil.WriteFile(filepath.Join(pkgDir, name), []byte(data), 0644); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0644); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, "main.go"), []byte(req.Program), 0644)
+}
+
+// limitedBuffer discards data beyond its limit.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if n := b.limit - b.Len(); n < len(p) {
+		if n > 0 {
+			b.Buffer.Write(p[:n])
+		}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+// buildEnv returns environment variables of go command, which excludes
+// variables of runner that are not needed by the toolchain.
+func buildEnv() []string {
+	env := []string{"CGO_ENABLED=0", "GOTOOLCHAIN=local", "GOFLAGS=-mod=mod", "GO111MODULE=on"}
+	for _, key := range []string{"PATH", "HOME", "GOPATH", "GOROOT", "GOCACHE", "GOMODCACHE", "GOPROXY", "GOSUMDB"} {
+		if value, ok := os.LookupEnv(key); ok {
+			env = append(env, key+"="+value)
+		}
+	}
+	return env
+}
+
+// build builds the program in dir, it returns build errors in the response
+// if the program is not valid.
+func (r *Runner) build(ctx context.Context, dir string) (*Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, buildTimeout)
+	defer cancel()
+
+	env := buildEnv()
+	for _, args := range [][]string{
+		{"mod", "tidy"},
+		{"build", "-o", "prog", "."},
+	} {
+		cmd := exec.CommandContext(ctx, r.cfg.GoBin, args...)
+		cmd.Dir = dir
+		cmd.Env = env
+		out, err := cmd.CombinedOutput()
+		if ctx.Err() == context.DeadlineExceeded {
+			return &Response{Errors: "build timed out", TimedOut: true}, nil
+		} else if _, ok := err.(*exec.ExitError); ok {
+			return &Response{Errors: strings.Replace(string(out), dir+string(filepath.Separator), "", -1), ExitCode: 2}, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("go %s: %v", args[0], err)
+		}
+	}
+	return nil, nil
+}
+
+// Run builds and runs the program of the request with limits.
+func (r *Runner) Run(ctx context.Context, req *Request) (*Response, error) {
+	if len(req.Program) == 0 {
+		return nil, errors.New("empty program")
+	}
+
+	dir, err := ioutil.TempDir("", "gowalker-play")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	if err = prepare(dir, req); err != nil {
+		return nil, fmt.Errorf("prepare: %v", err)
+	}
+	if resp, err := r.build(ctx, dir); resp != nil || err != nil {
+		return resp, err
+	}
+
+	attr, err := sandboxAttr()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(r.self, "sandbox",
+		"--cpu", strconv.Itoa(r.cfg.MaxCPU),
+		"--memory", strconv.Itoa(r.cfg.MaxMemory),
+		"--procs", strconv.Itoa(r.cfg.MaxProcs),
+		"--file-size", strconv.Itoa(r.cfg.MaxFileSize),
+		"--uid", strconv.Itoa(r.cfg.UID),
+		"--gid", strconv.Itoa(r.cfg.GID),
+		dir)
+	cmd.Env = []string{}
+	cmd.SysProcAttr = attr
+	stdout := &limitedBuffer{limit: maxOutputSize}
+	stderr := &limitedBuffer{limit: maxOutputSize}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	if err = cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start: %v", err)
+	}
+
+	// Programs are killed with all their descendants on timeout.
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.Timeout)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			killGroup(cmd.Process.Pid)
+		case <-done:
+		}
+	}()
+	err = cmd.Wait()
+	close(done)
+
+	resp := new(Response)
+	if ctx.Err() == context.DeadlineExceeded || cpuExceeded(cmd.ProcessState, r.cfg.MaxCPU) {
+		resp.TimedOut = true
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		resp.ExitCode = exitErr.ExitCode()
+	} else if err != nil {
+		return nil, fmt.Errorf("run: %v", err)
+	}
+	resp.Output = stdout.String()
+	resp.Errors = stderr.String()
+	return resp, nil
+}
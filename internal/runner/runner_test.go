@@ -0,0 +1,83 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package runner
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPrepare(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gowalker-runner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = prepare(dir, &Request{
+		ImportPath: "github.com/gowalker/hello",
+		Files:      map[string]string{"hello.go": "package hello\n"},
+		Program:    "package main\n",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"go.mod":       "module play\n\nrequire github.com/gowalker/hello v0.0.0\n\nreplace github.com/gowalker/hello => ./pkg\n",
+		"main.go":      "package main\n",
+		"pkg/go.mod":   "module github.com/gowalker/hello\n",
+		"pkg/hello.go": "package hello\n",
+	}
+	for name, content := range want {
+		data, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatal(err)
+		} else if string(data) != content {
+			t.Errorf("%s: got %q, want %q", name, data, content)
+		}
+	}
+
+	for _, name := range []string{"../main.go", "pkg/x.go", ".x.go", "go.mod"} {
+		dir, err := ioutil.TempDir("", "gowalker-runner")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		err = prepare(dir, &Request{
+			ImportPath: "github.com/gowalker/hello",
+			Files:      map[string]string{name: "package hello\n"},
+			Program:    "package main\n",
+		})
+		if err == nil {
+			t.Errorf("%q: expect error but got nil", name)
+		}
+	}
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	buf := &limitedBuffer{limit: 5}
+	for _, s := range []string{"abc", "defg", "hij"} {
+		if n, err := buf.Write([]byte(s)); err != nil || n != len(s) {
+			t.Fatalf("Write(%q): got (%d, %v)", s, n, err)
+		}
+	}
+	if got := buf.String(); got != "abcde" {
+		t.Errorf("got %q, want %q", got, "abcde")
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package runner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// sandboxAttr returns attributes to start sandbox in new network, PID and mount
+// namespaces, and in its own process group.
+func sandboxAttr() (*syscall.SysProcAttr, error) {
+	attr := &syscall.SysProcAttr{
+		Cloneflags: syscall.CLONE_NEWNET | syscall.CLONE_NEWPID | syscall.CLONE_NEWNS,
+		Setpgid:    true,
+		Pdeathsig:  syscall.SIGKILL,
+	}
+	// Unprivileged users are only allowed to create namespaces in their own
+	// user namespace, where they are root to set up the sandbox.
+	if uid := os.Getuid(); uid != 0 {
+		attr.Cloneflags |= syscall.CLONE_NEWUSER
+		attr.UidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: uid, Size: 1}}
+		attr.GidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getgid(), Size: 1}}
+	}
+	return attr, nil
+}
+
+// killGroup kills the process group of the sandbox.
+func killGroup(pid int) {
+	syscall.Kill(-pid, syscall.SIGKILL)
+}
+
+// cpuExceeded returns true if the process was killed for exceeding CPU time limit.
+// Go programs ignore SIGXCPU by default, so they are killed at the hard limit,
+// which is only told from other kills by CPU time used.
+func cpuExceeded(state *os.ProcessState, maxCPU int) bool {
+	status, ok := state.Sys().(syscall.WaitStatus)
+	if !ok || !status.Signaled() || maxCPU <= 0 {
+		return false
+	} else if status.Signal() == syscall.SIGXCPU {
+		return true
+	}
+	return status.Signal() == syscall.SIGKILL && state.UserTime()+state.SystemTime() >= time.Duration(maxCPU)*time.Second
+}
+
+func setrlimit(resource int, name string, limit uint64) error {
+	if err := unix.Setrlimit(resource, &unix.Rlimit{Cur: limit, Max: limit}); err != nil {
+		return fmt.Errorf("set %s limit: %v", name, err)
+	}
+	return nil
+}
+
+// Sandbox makes root directory of the program, which must be prepared by runner,
+// the read-only root of current process with a size-limited writable /tmp.
+// It then sets limits, drops privileges and replaces the process with the program.
+// It only returns on error.
+func Sandbox(cfg Config, root string) error {
+	// Mounts must not propagate back to the host.
+	if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("make mounts private: %v", err)
+	} else if err = syscall.Mount(root, root, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		return fmt.Errorf("bind root: %v", err)
+	}
+	opts := "mode=1777"
+	if cfg.MaxFileSize > 0 {
+		opts += fmt.Sprintf(",size=%dm", cfg.MaxFileSize)
+	}
+	if err := syscall.Mount("tmpfs", filepath.Join(root, "tmp"), "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV, opts); err != nil {
+		return fmt.Errorf("mount tmp: %v", err)
+	} else if err = syscall.Mount("", root, "", syscall.MS_BIND|syscall.MS_REMOUNT|syscall.MS_RDONLY|syscall.MS_NOSUID|syscall.MS_NODEV, ""); err != nil {
+		return fmt.Errorf("make root read-only: %v", err)
+	}
+
+	// Old root is detached so that it cannot be reached again like chroot.
+	if err := syscall.Chdir(root); err != nil {
+		return err
+	} else if err = syscall.PivotRoot(".", "."); err != nil {
+		return fmt.Errorf("pivot root: %v", err)
+	} else if err = syscall.Unmount(".", syscall.MNT_DETACH); err != nil {
+		return fmt.Errorf("detach old root: %v", err)
+	} else if err = syscall.Chdir("/"); err != nil {
+		return err
+	}
+
+	if cfg.MaxCPU > 0 {
+		if err := setrlimit(unix.RLIMIT_CPU, "CPU", uint64(cfg.MaxCPU)); err != nil {
+			return err
+		}
+	}
+	if cfg.MaxMemory > 0 {
+		if err := setrlimit(unix.RLIMIT_DATA, "memory", uint64(cfg.MaxMemory)<<20); err != nil {
+			return err
+		}
+	}
+	if cfg.MaxProcs > 0 {
+		if err := setrlimit(unix.RLIMIT_NPROC, "process", uint64(cfg.MaxProcs)); err != nil {
+			return err
+		}
+	}
+	if cfg.MaxFileSize > 0 {
+		if err := setrlimit(unix.RLIMIT_FSIZE, "file size", uint64(cfg.MaxFileSize)<<20); err != nil {
+			return err
+		}
+	}
+
+	if cfg.UID > 0 {
+		if err := syscall.Setgroups(nil); err != nil {
+			return fmt.Errorf("drop groups: %v", err)
+		} else if err = syscall.Setgid(cfg.GID); err != nil {
+			return fmt.Errorf("set gid: %v", err)
+		} else if err = syscall.Setuid(cfg.UID); err != nil {
+			return fmt.Errorf("set uid: %v", err)
+		}
+	}
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return fmt.Errorf("set no new privileges: %v", err)
+	}
+	return syscall.Exec("/prog", []string{"/prog"}, []string{"HOME=/tmp", "TMPDIR=/tmp"})
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build !linux
+// +build !linux
+
+package runner
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+var errUnsupported = errors.New("sandbox is only supported on Linux")
+
+func sandboxAttr() (*syscall.SysProcAttr, error) {
+	return nil, errUnsupported
+}
+
+func killGroup(pid int) {}
+
+func cpuExceeded(state *os.ProcessState, maxCPU int) bool {
+	return false
+}
+
+// Sandbox is only supported on Linux.
+func Sandbox(cfg Config, root string) error {
+	return errUnsupported
+}
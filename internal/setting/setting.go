@@ -34,6 +34,10 @@ var (
 	FetchTimeout time.Duration
 	DocsJSPath   string
 	DocsGobPath  string
+	SaveGob      bool
+	TypeCheck    bool
+	// GOOS/GOARCH pairs that documentation is generated for.
+	Platforms = []string{"linux/amd64", "darwin/amd64", "windows/amd64"}
 
 	DigitalOcean struct {
 		Spaces struct {
@@ -50,6 +54,30 @@ var (
 		JSRecycleDays int `ini:"JS_RECYCLE_DAYS"`
 	}
 
+	// Runner settings for running examples.
+	Runner struct {
+		Enabled     bool
+		Addr        string
+		GoBin       string
+		Timeout     int // In seconds.
+		MaxCPU      int `ini:"MAX_CPU"` // In seconds.
+		MaxMemory   int // In megabytes.
+		MaxProcs    int
+		MaxFileSize int // In megabytes.
+		// Unprivileged user and group to run examples when runner is started by root.
+		UID int `ini:"UID"`
+		GID int `ini:"GID"`
+	}
+
+	// Prewarm settings for crawling modules listed in uploaded go.mod or go.sum.
+	Prewarm struct {
+		Enabled       bool
+		Token         string // Required in "Authorization: token <TOKEN>" header of requests.
+		MaxModules    int
+		MaxPackages   int // Maximum number of packages crawled for each module.
+		MaxClientJobs int // Maximum number of unfinished jobs of each client.
+	}
+
 	// Global settings
 	Cfg    *ini.File
 	GitHub struct {
@@ -59,12 +87,18 @@ var (
 	RefreshInterval = 5 * time.Minute
 )
 
-func init() {
+// Init loads configuration from conf/app.ini and given custom configuration
+// file (custom/app.ini if empty), it must be called before any other package
+// reads settings.
+func Init(customConf string) {
 	log.New(log.CONSOLE, log.ConsoleConfig{})
 
+	if len(customConf) == 0 {
+		customConf = "custom/app.ini"
+	}
 	sources := []interface{}{"conf/app.ini"}
-	if com.IsFile("custom/app.ini") {
-		sources = append(sources, "custom/app.ini")
+	if com.IsFile(customConf) {
+		sources = append(sources, customConf)
 	}
 
 	var err error
@@ -92,6 +126,15 @@ func init() {
 	FetchTimeout = time.Duration(sec.Key("FETCH_TIMEOUT").MustInt(60)) * time.Second
 	DocsJSPath = sec.Key("DOCS_JS_PATH").MustString("raw/docs/")
 	DocsGobPath = sec.Key("DOCS_GOB_PATH").MustString("raw/gob/")
+	// Imported packages are type-checked from stored gob files.
+	TypeCheck = sec.Key("TYPE_CHECK").MustBool()
+	SaveGob = sec.Key("SAVE_GOB").MustBool(!ProdMode || TypeCheck)
+	if TypeCheck && !SaveGob {
+		log.Warn("TYPE_CHECK is enabled without SAVE_GOB, imported packages cannot be resolved")
+	}
+	if platforms := sec.Key("PLATFORMS").Strings(","); len(platforms) > 0 {
+		Platforms = platforms
+	}
 
 	if err = Cfg.Section("github").MapTo(&GitHub); err != nil {
 		log.Fatal(2, "Failed to map GitHub settings: %v", err)
@@ -99,6 +142,12 @@ func init() {
 		log.Fatal(2, "Failed to map DigitalOcean.Spaces settings: %v", err)
 	} else if err = Cfg.Section("maintenance").MapTo(&Maintenance); err != nil {
 		log.Fatal(2, "Failed to map Maintenance settings: %v", err)
+	} else if err = Cfg.Section("runner").MapTo(&Runner); err != nil {
+		log.Fatal(2, "Failed to map Runner settings: %v", err)
+	} else if err = Cfg.Section("prewarm").MapTo(&Prewarm); err != nil {
+		log.Fatal(2, "Failed to map Prewarm settings: %v", err)
+	} else if Prewarm.Enabled && len(Prewarm.Token) == 0 {
+		log.Fatal(2, "Pre-warming requires TOKEN in [prewarm] section")
 	}
 
 	sec = Cfg.Section("log.discord")
@@ -78,7 +78,7 @@ $(document).ready(function () {
             $('#search-results').html("");
             for (var i = 0; i < exportDataSrc.length; i++) {
                 if (exportDataSrc[i].title.toLowerCase().includes($(this).val().toLowerCase())) {
-                    $('#search-results').append(`<a href="#` + exportDataSrc[i].title.replace(/\./g, "_") + `">
+                    $('#search-results').append(`<a href="#` + (exportDataSrc[i].anchor || exportDataSrc[i].title.replace(/\./g, "_")) + `">
                     <div class="tile tile-centered">
                         <div class="tile-content">` + exportDataSrc[i].title + `</div>
                     </div>
@@ -161,6 +161,55 @@ $(document).ready(function () {
         event.preventDefault();
     });
 
+    // Run example.
+    $('.run-example').click(function () {
+        var $result = $($(this).data('target'));
+        var $output = $result.find('.result');
+        $result.removeClass('d-hide');
+        $result.find('.mismatch').addClass('d-hide');
+        $output.text('Waiting for remote server...');
+        $.getJSON(window.location.pathname + '?play=' + encodeURIComponent($(this).data('name')), function (data) {
+            var text = data.output;
+            if (data.errors) {
+                text += data.errors;
+            }
+            if (data.timed_out) {
+                text += '\nProgram timed out.';
+            }
+            $output.text(text);
+            $result.find('.mismatch').toggleClass('d-hide', !data.mismatch);
+        }).fail(function (xhr) {
+            $output.text(xhr.responseJSON ? xhr.responseJSON.error : xhr.statusText);
+        });
+    });
+
+    // Load types of other packages that implement the interface.
+    $('.load-implementors').click(function () {
+        var $list = $($(this).data('target'));
+        $(this).remove();
+        $list.removeClass('d-hide').text('Loading...');
+        $.getJSON(window.location.pathname + '?implementors=' + encodeURIComponent($(this).data('name')), function (data) {
+            $list.empty();
+            if (!data.implementors.length) {
+                $list.append($('<li>').text('No types found in indexed packages.'));
+            }
+            $.each(data.implementors, function (_, impl) {
+                $list.append($('<li>').append($('<a>').attr('href', impl.path).text((impl.ptr ? '*' : '') + impl.name)));
+            });
+        }).fail(function (xhr) {
+            $list.text(xhr.responseJSON ? xhr.responseJSON.error : xhr.statusText);
+        });
+    });
+
+    // Filter declarations by platform.
+    $('#platform-select').change(function () {
+        var platform = $(this).val();
+        $('[data-platforms]').each(function () {
+            var platforms = $(this).attr('data-platforms');
+            $(this).toggle(!platform || !platforms || platforms.split(' ').indexOf(platform) > -1);
+        });
+    });
+
     // Browse history.
     if ($('#browse_history').length) {
         $(this).each(function () {